@@ -0,0 +1,160 @@
+package apitest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/MitiaRD/ReMarkable-cli/api"
+	"github.com/MitiaRD/ReMarkable-cli/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func seededSpaceX() *SpaceX {
+	success := true
+	return NewSpaceX().
+		WithRockets(model.Rocket{ID: "falcon9", Name: "Falcon 9"}).
+		WithLaunchpads(model.Launchpad{ID: "slc40", Name: "Cape Canaveral SLC-40"}).
+		WithCrew(model.Crew{ID: "bob", Name: "Robert Behnken"}).
+		WithLaunches(
+			model.Launch{ID: "1", FlightNumber: 1, Name: "One", Date: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), RocketId: "falcon9", LaunchpadId: "slc40", Success: &success},
+			model.Launch{ID: "2", FlightNumber: 2, Name: "Two", Date: time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC), RocketId: "falcon9", Crew: []string{"bob"}},
+			model.Launch{ID: "3", FlightNumber: 3, Name: "Three", Date: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), RocketId: "falcon9", Upcoming: true},
+		)
+}
+
+func TestSpaceXQueryLaunches(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    *api.LaunchQuery
+		wantIDs  []string
+		wantNext bool
+	}{
+		{
+			name:    "no filter",
+			query:   api.NewLaunchQuery(),
+			wantIDs: []string{"1", "2", "3"},
+		},
+		{
+			name:    "sorted newest first",
+			query:   api.NewLaunchQuery().SortBy("date_utc", api.Descending),
+			wantIDs: []string{"3", "2", "1"},
+		},
+		{
+			name:    "date range",
+			query:   api.NewLaunchQuery().Between(time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)),
+			wantIDs: []string{"2", "3"},
+		},
+		{
+			name:    "upcoming and success",
+			query:   api.NewLaunchQuery().Upcoming(false).Success(true),
+			wantIDs: []string{"1"},
+		},
+		{
+			name:    "crew",
+			query:   api.NewLaunchQuery().Crew("bob"),
+			wantIDs: []string{"2"},
+		},
+		{
+			name:     "first page",
+			query:    api.NewLaunchQuery().Limit(2),
+			wantIDs:  []string{"1", "2"},
+			wantNext: true,
+		},
+		{
+			name:    "second page",
+			query:   api.NewLaunchQuery().Limit(2).Page(2),
+			wantIDs: []string{"3"},
+		},
+		{
+			name:     "offset",
+			query:    api.NewLaunchQuery().Limit(1).Offset(1),
+			wantIDs:  []string{"2"},
+			wantNext: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := seededSpaceX().QueryLaunches(context.Background(), tt.query)
+			require.NoError(t, err)
+
+			var ids []string
+			for _, launch := range page.Docs {
+				ids = append(ids, launch.ID)
+			}
+			assert.Equal(t, tt.wantIDs, ids)
+			assert.Equal(t, tt.wantNext, page.HasNextPage)
+		})
+	}
+}
+
+func TestSpaceXPopulatesAndIterates(t *testing.T) {
+	fake := seededSpaceX()
+	query := api.NewLaunchQuery().Limit(1).Populate("rocket", "launchpad", "crew")
+
+	var launches []model.Launch
+	for launch, err := range api.IterateLaunches(context.Background(), fake, query) {
+		require.NoError(t, err)
+		launches = append(launches, launch)
+	}
+
+	require.Len(t, launches, 3)
+	assert.Equal(t, "Falcon 9", launches[0].Rocket.Name)
+	assert.Equal(t, "Cape Canaveral SLC-40", launches[0].Launchpad.Name)
+	assert.Equal(t, "Robert Behnken", launches[1].CrewMembers[0].Name)
+	assert.Equal(t, []string{"QueryLaunches", "QueryLaunches", "QueryLaunches"}, fake.Calls())
+}
+
+func TestFakesInjectErrorsAndLatency(t *testing.T) {
+	errDown := errors.New("upstream down")
+	fake := NewSpaceX().FailOn("GetAllRockets", errDown)
+
+	_, err := fake.GetAllRockets(context.Background())
+	assert.ErrorIs(t, err, errDown)
+	_, err = fake.GetAllCrewMembers(context.Background())
+	assert.NoError(t, err)
+
+	nasa := NewNASA().FailAll(errDown)
+	_, err = nasa.GetAsteroids(context.Background(), "?start_date=2024-01-01&end_date=2024-01-01")
+	assert.ErrorIs(t, err, errDown)
+
+	slow := NewNASA().WithLatency(time.Hour)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = slow.GetEarthEvents(ctx, nil)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestNASAFiltersSeededData(t *testing.T) {
+	day := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	nasa := NewNASA().
+		WithAsteroids(day, model.NasaAsteroidObject{Hazardous: true}, model.NasaAsteroidObject{}).
+		WithAsteroids(day.AddDate(0, 0, 5), model.NasaAsteroidObject{}).
+		WithEvents(
+			earthEvent("near", -80.5, 28.5, "2024-01-02T12:00:00Z"),
+			earthEvent("far", 10, 50, "2024-01-02T12:00:00Z"),
+			earthEvent("later", -80.5, 28.5, "2024-02-01T00:00:00Z"),
+		)
+
+	feed, err := nasa.GetAsteroids(context.Background(), "?start_date=2024-01-01&end_date=2024-01-03")
+	require.NoError(t, err)
+	assert.Equal(t, 2, feed.ElementCount)
+	assert.Len(t, feed.NearEarthObjects, 3)
+	assert.True(t, feed.NearEarthObjects["2024-01-02"][0].Hazardous)
+
+	events, err := nasa.GetEarthEvents(context.Background(), api.HazardQuery(-80.6, 28.6, day, 100, 0))
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, "near", events[0].ID)
+}
+
+func earthEvent(id string, lon, lat float64, date string) model.NasaEarthEvent {
+	observed, _ := time.Parse(time.RFC3339, date)
+	return model.NasaEarthEvent{
+		ID:       id,
+		Geometry: []model.EventGeometry{{Type: model.GeometryPoint, Point: []float64{lon, lat}, Date: observed}},
+	}
+}
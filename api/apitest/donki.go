@@ -0,0 +1,104 @@
+package apitest
+
+import (
+	"context"
+	"time"
+
+	"github.com/MitiaRD/ReMarkable-cli/api"
+	"github.com/MitiaRD/ReMarkable-cli/model"
+)
+
+// DONKI is an in-memory api.DONKIAPI. Like the real endpoints it returns the
+// events that started on any day from start to end.
+type DONKI struct {
+	behaviour
+	flares    []model.SolarFlare
+	cmes      []model.CME
+	storms    []model.GeomagneticStorm
+	particles []model.SolarEnergeticParticle
+}
+
+var _ api.DONKIAPI = (*DONKI)(nil)
+
+func NewDONKI() *DONKI {
+	return &DONKI{}
+}
+
+func (f *DONKI) WithFlares(flares ...model.SolarFlare) *DONKI {
+	f.flares = append(f.flares, flares...)
+	return f
+}
+
+func (f *DONKI) WithCMEs(cmes ...model.CME) *DONKI {
+	f.cmes = append(f.cmes, cmes...)
+	return f
+}
+
+func (f *DONKI) WithStorms(storms ...model.GeomagneticStorm) *DONKI {
+	f.storms = append(f.storms, storms...)
+	return f
+}
+
+func (f *DONKI) WithParticles(particles ...model.SolarEnergeticParticle) *DONKI {
+	f.particles = append(f.particles, particles...)
+	return f
+}
+
+// WithLatency delays every call by d, or until the call's context is done.
+func (f *DONKI) WithLatency(d time.Duration) *DONKI {
+	f.setLatency(d)
+	return f
+}
+
+// FailOn makes the named method, e.g. "GetCMEs", return err.
+func (f *DONKI) FailOn(method string, err error) *DONKI {
+	f.failOn(method, err)
+	return f
+}
+
+// FailAll makes every method without a FailOn error return err.
+func (f *DONKI) FailAll(err error) *DONKI {
+	f.failAll(err)
+	return f
+}
+
+func (f *DONKI) GetSolarFlares(ctx context.Context, start, end time.Time) ([]model.SolarFlare, error) {
+	if err := f.call(ctx, "GetSolarFlares"); err != nil {
+		return nil, err
+	}
+	return between(f.flares, start, end, func(flare model.SolarFlare) time.Time { return flare.BeginTime.Time }), nil
+}
+
+func (f *DONKI) GetCMEs(ctx context.Context, start, end time.Time) ([]model.CME, error) {
+	if err := f.call(ctx, "GetCMEs"); err != nil {
+		return nil, err
+	}
+	return between(f.cmes, start, end, func(cme model.CME) time.Time { return cme.StartTime.Time }), nil
+}
+
+func (f *DONKI) GetGeomagneticStorms(ctx context.Context, start, end time.Time) ([]model.GeomagneticStorm, error) {
+	if err := f.call(ctx, "GetGeomagneticStorms"); err != nil {
+		return nil, err
+	}
+	return between(f.storms, start, end, func(storm model.GeomagneticStorm) time.Time { return storm.StartTime.Time }), nil
+}
+
+func (f *DONKI) GetSEPs(ctx context.Context, start, end time.Time) ([]model.SolarEnergeticParticle, error) {
+	if err := f.call(ctx, "GetSEPs"); err != nil {
+		return nil, err
+	}
+	return between(f.particles, start, end, func(sep model.SolarEnergeticParticle) time.Time { return sep.EventTime.Time }), nil
+}
+
+// between keeps the events whose time falls on a day from start to end.
+func between[T any](events []T, start, end time.Time, at func(T) time.Time) []T {
+	first := start.UTC().Truncate(24 * time.Hour)
+	last := end.UTC().Truncate(24*time.Hour).AddDate(0, 0, 1)
+	matched := []T{}
+	for _, event := range events {
+		if t := at(event); !t.Before(first) && t.Before(last) {
+			matched = append(matched, event)
+		}
+	}
+	return matched
+}
@@ -0,0 +1,73 @@
+// Package apitest provides in-memory implementations of the api client
+// interfaces for tests that must not touch the network.
+package apitest
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// behaviour holds the call log and the injected latency and errors shared
+// by every fake.
+type behaviour struct {
+	mu      sync.Mutex
+	latency time.Duration
+	err     error
+	errors  map[string]error
+	calls   []string
+}
+
+func (b *behaviour) setLatency(d time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.latency = d
+}
+
+func (b *behaviour) failOn(method string, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.errors == nil {
+		b.errors = make(map[string]error)
+	}
+	b.errors[method] = err
+}
+
+func (b *behaviour) failAll(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.err = err
+}
+
+// Calls returns the names of the methods called so far, in order.
+func (b *behaviour) Calls() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]string(nil), b.calls...)
+}
+
+// call records method, waits for the configured latency and returns the
+// error injected for method, if any.
+func (b *behaviour) call(ctx context.Context, method string) error {
+	b.mu.Lock()
+	b.calls = append(b.calls, method)
+	latency := b.latency
+	err := b.err
+	if methodErr, ok := b.errors[method]; ok {
+		err = methodErr
+	}
+	b.mu.Unlock()
+
+	if latency > 0 {
+		timer := time.NewTimer(latency)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+		}
+	} else if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return err
+}
@@ -0,0 +1,145 @@
+package apitest
+
+import (
+	"context"
+	"time"
+
+	"github.com/MitiaRD/ReMarkable-cli/api"
+	"github.com/MitiaRD/ReMarkable-cli/model"
+)
+
+// LaunchLibrary is an in-memory api.LaunchLibraryAPI. Launches are filtered,
+// sorted and paginated like the SpaceX fake and, like the real API, always
+// embed their rocket and launchpad when those were seeded.
+type LaunchLibrary struct {
+	behaviour
+	name       string
+	launches   []model.Launch
+	rockets    []model.Rocket
+	launchpads []model.Launchpad
+	agencies   []model.Agency
+}
+
+var _ api.LaunchLibraryAPI = (*LaunchLibrary)(nil)
+
+func NewLaunchLibrary() *LaunchLibrary {
+	return &LaunchLibrary{}
+}
+
+// WithName makes the fake stand in for another launch provider, e.g. one
+// added with api.RegisterProvider.
+func (f *LaunchLibrary) WithName(name string) *LaunchLibrary {
+	f.name = name
+	return f
+}
+
+func (f *LaunchLibrary) WithLaunches(launches ...model.Launch) *LaunchLibrary {
+	f.launches = append(f.launches, launches...)
+	return f
+}
+
+func (f *LaunchLibrary) WithRockets(rockets ...model.Rocket) *LaunchLibrary {
+	f.rockets = append(f.rockets, rockets...)
+	return f
+}
+
+func (f *LaunchLibrary) WithLaunchpads(launchpads ...model.Launchpad) *LaunchLibrary {
+	f.launchpads = append(f.launchpads, launchpads...)
+	return f
+}
+
+func (f *LaunchLibrary) WithAgencies(agencies ...model.Agency) *LaunchLibrary {
+	f.agencies = append(f.agencies, agencies...)
+	return f
+}
+
+// WithLatency delays every call by d, or until the call's context is done.
+func (f *LaunchLibrary) WithLatency(d time.Duration) *LaunchLibrary {
+	f.setLatency(d)
+	return f
+}
+
+// FailOn makes the named method, e.g. "QueryLaunches", return err.
+func (f *LaunchLibrary) FailOn(method string, err error) *LaunchLibrary {
+	f.failOn(method, err)
+	return f
+}
+
+// FailAll makes every method without a FailOn error return err.
+func (f *LaunchLibrary) FailAll(err error) *LaunchLibrary {
+	f.failAll(err)
+	return f
+}
+
+func (f *LaunchLibrary) Name() string {
+	if f.name != "" {
+		return f.name
+	}
+	return model.ProviderLaunchLibrary
+}
+
+func (f *LaunchLibrary) QueryLaunches(ctx context.Context, query *api.LaunchQuery) (api.Page[model.Launch], error) {
+	if err := f.call(ctx, "QueryLaunches"); err != nil {
+		return api.Page[model.Launch]{}, err
+	}
+	if query == nil {
+		query = api.NewLaunchQuery()
+	}
+
+	var matches []model.Launch
+	for _, launch := range f.launches {
+		if query.Matches(launch) {
+			matches = append(matches, launch)
+		}
+	}
+
+	options := query.Options()
+	api.SortLaunches(matches, options.Sort)
+	page := api.Paginate(matches, options)
+	rockets := byID(f.rockets, func(r model.Rocket) string { return r.ID })
+	launchpads := byID(f.launchpads, func(p model.Launchpad) string { return p.ID })
+	for i, launch := range page.Docs {
+		if rocket, ok := rockets[launch.RocketId]; ok {
+			page.Docs[i].Rocket = &rocket
+		}
+		if launchpad, ok := launchpads[launch.LaunchpadId]; ok {
+			page.Docs[i].Launchpad = &launchpad
+		}
+	}
+	return page, nil
+}
+
+func (f *LaunchLibrary) GetRockets(ctx context.Context) (map[string]model.Rocket, error) {
+	if err := f.call(ctx, "GetRockets"); err != nil {
+		return nil, err
+	}
+	return byID(f.rockets, func(r model.Rocket) string { return r.ID }), nil
+}
+
+func (f *LaunchLibrary) GetLaunchpads(ctx context.Context) (map[string]model.Launchpad, error) {
+	if err := f.call(ctx, "GetLaunchpads"); err != nil {
+		return nil, err
+	}
+	return byID(f.launchpads, func(p model.Launchpad) string { return p.ID }), nil
+}
+
+func (f *LaunchLibrary) QueryAgencies(ctx context.Context, query *api.LibraryQuery) (api.Page[model.Agency], error) {
+	if err := f.call(ctx, "QueryAgencies"); err != nil {
+		return api.Page[model.Agency]{}, err
+	}
+	if query == nil {
+		query = api.NewLibraryQuery()
+	}
+
+	var matches []model.Agency
+	for _, agency := range f.agencies {
+		ok, err := matchesLibrary(query, agency)
+		if err != nil {
+			return api.Page[model.Agency]{}, err
+		}
+		if ok {
+			matches = append(matches, agency)
+		}
+	}
+	return api.Paginate(matches, query.Options()), nil
+}
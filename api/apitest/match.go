@@ -0,0 +1,299 @@
+package apitest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/MitiaRD/ReMarkable-cli/api"
+	"github.com/MitiaRD/ReMarkable-cli/model"
+)
+
+// The fakes filter seeded data by what a query sends over the wire, its
+// query string or JSON body, so they answer the way the real server would
+// without the api types having to know how to match documents.
+
+// encodedParams decodes a query string as returned by the Encode methods.
+func encodedParams(encoded string) (url.Values, error) {
+	return url.ParseQuery(strings.TrimPrefix(encoded, "?"))
+}
+
+// eventFilter is an EONET /events query string, decoded.
+type eventFilter struct {
+	status     api.EventStatus
+	categories []string
+	sources    []string
+	days       int
+	limit      int
+	start      string
+	end        string
+	magMin     *float64
+	magMax     *float64
+	// bbox holds minLon, minLat, maxLon and maxLat.
+	bbox []float64
+}
+
+func parseEventQuery(query *api.EventQuery) (eventFilter, error) {
+	params, err := encodedParams(query.Encode())
+	if err != nil {
+		return eventFilter{}, err
+	}
+	filter := eventFilter{
+		status: api.EventStatus(params.Get("status")),
+		start:  params.Get("start"),
+		end:    params.Get("end"),
+	}
+	if categories := params.Get("category"); categories != "" {
+		filter.categories = strings.Split(categories, ",")
+	}
+	if sources := params.Get("source"); sources != "" {
+		filter.sources = strings.Split(sources, ",")
+	}
+	for param, dest := range map[string]*int{"days": &filter.days, "limit": &filter.limit} {
+		if value := params.Get(param); value != "" {
+			if *dest, err = strconv.Atoi(value); err != nil {
+				return eventFilter{}, fmt.Errorf("invalid %s %q", param, value)
+			}
+		}
+	}
+	for param, dest := range map[string]**float64{"magMin": &filter.magMin, "magMax": &filter.magMax} {
+		if value := params.Get(param); value != "" {
+			f, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return eventFilter{}, fmt.Errorf("invalid %s %q", param, value)
+			}
+			*dest = &f
+		}
+	}
+	if bbox := params.Get("bbox"); bbox != "" {
+		var corners []float64
+		for _, part := range strings.Split(bbox, ",") {
+			f, err := strconv.ParseFloat(part, 64)
+			if err != nil {
+				return eventFilter{}, fmt.Errorf("invalid bbox %q", bbox)
+			}
+			corners = append(corners, f)
+		}
+		if len(corners) != 4 {
+			return eventFilter{}, fmt.Errorf("invalid bbox %q", bbox)
+		}
+		// EONET takes the upper-left corner followed by the lower-right one.
+		filter.bbox = []float64{corners[0], corners[3], corners[2], corners[1]}
+	}
+	return filter, nil
+}
+
+// filterEvents returns the events EONET would return for query, relative to
+// now, up to its limit.
+func filterEvents(query *api.EventQuery, events []model.NasaEarthEvent, now time.Time) ([]model.NasaEarthEvent, error) {
+	filter, err := parseEventQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	matches := []model.NasaEarthEvent{}
+	for _, event := range events {
+		if filter.limit > 0 && len(matches) == filter.limit {
+			break
+		}
+		if filter.matches(event, now) {
+			matches = append(matches, event)
+		}
+	}
+	return matches, nil
+}
+
+func (f eventFilter) matches(event model.NasaEarthEvent, now time.Time) bool {
+	switch f.status {
+	case "", api.EventsOpen:
+		if !event.IsOpen() {
+			return false
+		}
+	case api.EventsClosed:
+		if event.IsOpen() {
+			return false
+		}
+	}
+	if len(f.categories) > 0 && !slices.ContainsFunc(event.Categories, func(c model.EventCategory) bool {
+		return slices.Contains(f.categories, c.ID)
+	}) {
+		return false
+	}
+	if len(f.sources) > 0 && !slices.ContainsFunc(event.Sources, func(s model.EventSource) bool {
+		return slices.Contains(f.sources, s.ID)
+	}) {
+		return false
+	}
+	return slices.ContainsFunc(event.Geometry, func(g model.EventGeometry) bool {
+		return f.matchesGeometry(g, now)
+	})
+}
+
+func (f eventFilter) matchesGeometry(g model.EventGeometry, now time.Time) bool {
+	if f.days > 0 && g.Date.Before(now.AddDate(0, 0, -f.days)) {
+		return false
+	}
+	day := g.Date.Format(dateLayout)
+	if f.start != "" && day < f.start {
+		return false
+	}
+	if f.end != "" && day > f.end {
+		return false
+	}
+	if f.magMin != nil || f.magMax != nil {
+		if g.MagnitudeValue == nil {
+			return false
+		}
+		if f.magMin != nil && *g.MagnitudeValue < *f.magMin {
+			return false
+		}
+		if f.magMax != nil && *g.MagnitudeValue > *f.magMax {
+			return false
+		}
+	}
+	if f.bbox != nil {
+		return slices.ContainsFunc(g.Positions(), func(position []float64) bool {
+			lon, lat := position[0], position[1]
+			return lon >= f.bbox[0] && lat >= f.bbox[1] && lon <= f.bbox[2] && lat <= f.bbox[3]
+		})
+	}
+	return true
+}
+
+// filterAPODs returns the entries the APOD endpoint would return for query,
+// given every picture published up to today. A count picks the first n
+// pictures instead of random ones.
+func filterAPODs(query *api.APODQuery, apods []model.APOD, today time.Time) ([]model.APOD, error) {
+	params, err := encodedParams(query.Encode())
+	if err != nil {
+		return nil, err
+	}
+	count := 0
+	if value := params.Get("count"); value != "" {
+		if count, err = strconv.Atoi(value); err != nil {
+			return nil, fmt.Errorf("invalid count %q", value)
+		}
+	}
+	date := params.Get("date")
+	if date == "" {
+		date = today.Format(time.DateOnly)
+	}
+	start, end := params.Get("start_date"), params.Get("end_date")
+	if end == "" {
+		end = today.Format(time.DateOnly)
+	}
+
+	matches := []model.APOD{}
+	for _, apod := range apods {
+		switch {
+		case count > 0:
+			if len(matches) == count {
+				return matches, nil
+			}
+		case start != "":
+			if apod.Date < start || apod.Date > end {
+				continue
+			}
+		default:
+			if apod.Date != date {
+				continue
+			}
+		}
+		if params.Get("thumbs") != "true" {
+			apod.ThumbnailURL = ""
+		}
+		matches = append(matches, apod)
+	}
+	return matches, nil
+}
+
+// matchesLibrary reports whether doc satisfies a Launch Library 2 list
+// query: the search term must appear in the name or abbreviation and every
+// other parameter must equal the JSON field of the same name.
+func matchesLibrary(query *api.LibraryQuery, doc any) (bool, error) {
+	params, err := encodedParams(query.Encode())
+	if err != nil {
+		return false, err
+	}
+	fields, err := jsonFields(doc)
+	if err != nil {
+		return false, err
+	}
+	for param := range params {
+		value := params.Get(param)
+		if param == "search" {
+			name, _ := fields["name"].(string)
+			abbrev, _ := fields["abbrev"].(string)
+			term := strings.ToLower(value)
+			if !strings.Contains(strings.ToLower(name), term) && !strings.Contains(strings.ToLower(abbrev), term) {
+				return false, nil
+			}
+			continue
+		}
+		if fmt.Sprint(fields[param]) != value {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// matchesCollection reports whether doc satisfies the filter of a SpaceX
+// /query body. Fields are compared by their JSON values; arrays match when
+// any element does and {"$in": [...]} when any of its values does.
+func matchesCollection(query *api.CollectionQuery, doc any) (bool, error) {
+	data, err := json.Marshal(query)
+	if err != nil {
+		return false, err
+	}
+	var body struct {
+		Query map[string]any `json:"query"`
+	}
+	if err := json.Unmarshal(data, &body); err != nil {
+		return false, err
+	}
+	fields, err := jsonFields(doc)
+	if err != nil {
+		return false, err
+	}
+
+	for field, want := range body.Query {
+		candidates := []any{want}
+		if in, ok := want.(map[string]any); ok {
+			candidates, _ = in["$in"].([]any)
+		}
+		value := fields[field]
+		if field == "_id" {
+			value = fields["id"]
+		}
+		if !slices.ContainsFunc(candidates, func(candidate any) bool {
+			return fieldMatches(value, candidate)
+		}) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// fieldMatches compares a decoded JSON field with a wanted value. Arrays
+// match when any element does.
+func fieldMatches(value, want any) bool {
+	if values, ok := value.([]any); ok {
+		return slices.ContainsFunc(values, func(v any) bool { return reflect.DeepEqual(v, want) })
+	}
+	return reflect.DeepEqual(value, want)
+}
+
+// jsonFields decodes the JSON representation of doc into its top-level
+// fields.
+func jsonFields(doc any) (map[string]any, error) {
+	fields := map[string]any{}
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	return fields, json.Unmarshal(data, &fields)
+}
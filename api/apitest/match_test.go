@@ -0,0 +1,163 @@
+package apitest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/MitiaRD/ReMarkable-cli/api"
+	"github.com/MitiaRD/ReMarkable-cli/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilterEvents(t *testing.T) {
+	now := time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC)
+	closed := now.AddDate(0, 0, -2)
+	knots := 95.0
+
+	storm := model.NasaEarthEvent{
+		ID:         "EONET_1",
+		Closed:     &closed,
+		Categories: []model.EventCategory{{ID: "severeStorms"}},
+		Sources:    []model.EventSource{{ID: "JTWC"}},
+		Geometry: []model.EventGeometry{{
+			Date:           now.AddDate(0, 0, -5),
+			Type:           model.GeometryPoint,
+			Point:          []float64{-80.6, 28.6},
+			MagnitudeValue: &knots,
+			MagnitudeUnit:  "kts",
+		}},
+	}
+	fire := model.NasaEarthEvent{
+		ID:         "EONET_2",
+		Categories: []model.EventCategory{{ID: "wildfires"}},
+		Geometry: []model.EventGeometry{{
+			Date:    now.AddDate(0, 0, -30),
+			Type:    model.GeometryPolygon,
+			Polygon: [][][]float64{{{-120, 35}, {-119, 35}, {-119, 36}, {-120, 35}}},
+		}},
+	}
+
+	tests := []struct {
+		name  string
+		query *api.EventQuery
+		want  []string
+	}{
+		{name: "nil query lists open events", query: nil, want: []string{"EONET_2"}},
+		{name: "default status is open", query: api.NewEventQuery(), want: []string{"EONET_2"}},
+		{name: "all statuses", query: api.NewEventQuery().Status(api.EventsAll), want: []string{"EONET_1", "EONET_2"}},
+		{name: "category", query: api.NewEventQuery().Status(api.EventsAll).Category("severeStorms"), want: []string{"EONET_1"}},
+		{name: "source", query: api.NewEventQuery().Status(api.EventsAll).Source("InciWeb"), want: []string{}},
+		{name: "days", query: api.NewEventQuery().Status(api.EventsAll).Days(10), want: []string{"EONET_1"}},
+		{name: "dates", query: api.NewEventQuery().Status(api.EventsAll).Between(now.AddDate(0, 0, -31), now.AddDate(0, 0, -29)), want: []string{"EONET_2"}},
+		{name: "magnitude", query: api.NewEventQuery().Status(api.EventsAll).MagnitudeMin("mag_kts", 90), want: []string{"EONET_1"}},
+		{name: "magnitude too high", query: api.NewEventQuery().Status(api.EventsAll).MagnitudeMin("mag_kts", 100), want: []string{}},
+		{name: "polygon vertex in bbox", query: api.NewEventQuery().Status(api.EventsAll).BBox(-119.5, 35.5, -118, 37), want: []string{"EONET_2"}},
+		{name: "limit", query: api.NewEventQuery().Status(api.EventsAll).Limit(1), want: []string{"EONET_1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events, err := filterEvents(tt.query, []model.NasaEarthEvent{storm, fire}, now)
+			require.NoError(t, err)
+			ids := []string{}
+			for _, event := range events {
+				ids = append(ids, event.ID)
+			}
+			assert.Equal(t, tt.want, ids)
+		})
+	}
+}
+
+func TestFilterAPODs(t *testing.T) {
+	today := time.Date(2024, 9, 28, 12, 0, 0, 0, time.UTC)
+	apods := []model.APOD{
+		{Date: "2024-09-25"},
+		{Date: "2024-09-26", MediaType: model.MediaVideo, ThumbnailURL: "https://img.youtube.com/thumb.jpg"},
+		{Date: "2024-09-27"},
+		{Date: "2024-09-28"},
+	}
+	dates := func(query *api.APODQuery) []string {
+		t.Helper()
+		matches, err := filterAPODs(query, apods, today)
+		require.NoError(t, err)
+		result := []string{}
+		for _, apod := range matches {
+			result = append(result, apod.Date)
+		}
+		return result
+	}
+
+	assert.Equal(t, []string{"2024-09-28"}, dates(nil))
+	assert.Equal(t, []string{"2024-09-28"}, dates(api.NewAPODQuery()))
+	assert.Equal(t, []string{"2024-09-26", "2024-09-27"}, dates(api.NewAPODQuery().Between(time.Date(2024, 9, 26, 0, 0, 0, 0, time.UTC), time.Date(2024, 9, 27, 0, 0, 0, 0, time.UTC))))
+	assert.Equal(t, []string{"2024-09-27", "2024-09-28"}, dates(api.NewAPODQuery().Between(time.Date(2024, 9, 27, 0, 0, 0, 0, time.UTC), time.Time{})))
+	assert.Equal(t, []string{"2024-09-25", "2024-09-26"}, dates(api.NewAPODQuery().Count(2)))
+
+	video := api.NewAPODQuery().Date(time.Date(2024, 9, 26, 0, 0, 0, 0, time.UTC))
+	matches, err := filterAPODs(video, apods, today)
+	require.NoError(t, err)
+	assert.Empty(t, matches[0].ThumbnailURL)
+	matches, err = filterAPODs(video.Thumbs(true), apods, today)
+	require.NoError(t, err)
+	assert.NotEmpty(t, matches[0].ThumbnailURL)
+}
+
+func TestMatchesLibrary(t *testing.T) {
+	agency := model.Agency{Name: "Indian Space Research Organization", Abbrev: "ISRO", CountryCode: "IND", Type: "Government"}
+
+	tests := []struct {
+		name     string
+		query    *api.LibraryQuery
+		expected bool
+	}{
+		{name: "empty query", query: api.NewLibraryQuery(), expected: true},
+		{name: "search by name", query: api.NewLibraryQuery().Search("research"), expected: true},
+		{name: "search by abbreviation", query: api.NewLibraryQuery().Search("isro"), expected: true},
+		{name: "search misses", query: api.NewLibraryQuery().Search("nasa"), expected: false},
+		{name: "matching filters", query: api.NewLibraryQuery().Where("country_code", "IND").Where("type", "Government"), expected: true},
+		{name: "filter misses", query: api.NewLibraryQuery().Where("type", "Commercial"), expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ok, err := matchesLibrary(tt.query, agency)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, ok)
+		})
+	}
+}
+
+func TestMatchesCollection(t *testing.T) {
+	query := api.NewCollectionQuery().
+		Where("status", "active").
+		In("launches", "l1", "l2")
+
+	type core struct {
+		ID       string   `json:"id"`
+		Status   string   `json:"status"`
+		Block    int      `json:"block"`
+		Launches []string `json:"launches"`
+	}
+	tests := []struct {
+		name  string
+		query *api.CollectionQuery
+		doc   core
+		want  bool
+	}{
+		{name: "empty query", query: api.NewCollectionQuery(), doc: core{}, want: true},
+		{name: "field and array match", query: query, doc: core{Status: "active", Launches: []string{"l0", "l2"}}, want: true},
+		{name: "array without match", query: query, doc: core{Status: "active", Launches: []string{"l3"}}, want: false},
+		{name: "field mismatch", query: query, doc: core{Status: "lost", Launches: []string{"l1"}}, want: false},
+		{name: "numbers", query: api.NewCollectionQuery().Where("block", 5), doc: core{Block: 5}, want: true},
+		{name: "empty in matches nothing", query: api.NewCollectionQuery().In("status"), doc: core{Status: "active"}, want: false},
+		{name: "_id is the id field", query: api.NewCollectionQuery().In("_id", "c1"), doc: core{ID: "c1"}, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ok, err := matchesCollection(tt.query, tt.doc)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, ok)
+		})
+	}
+}
@@ -0,0 +1,239 @@
+package apitest
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/MitiaRD/ReMarkable-cli/api"
+	"github.com/MitiaRD/ReMarkable-cli/model"
+)
+
+const dateLayout = "2006-01-02"
+
+// NASA is an in-memory api.NASAAPI serving seeded NeoWs and EONET data.
+// Feed and event queries are filtered like the real endpoints.
+type NASA struct {
+	behaviour
+	asteroids map[string][]model.NasaAsteroidObject
+	catalogue []model.NasaAsteroidObject
+	events    []model.NasaEarthEvent
+	weather   map[string]model.DailyWeather
+	apods     []model.APOD
+	media     map[string][]byte
+	quota     api.RateLimitStatus
+}
+
+var _ api.NASAAPI = (*NASA)(nil)
+
+func NewNASA() *NASA {
+	return &NASA{
+		asteroids: make(map[string][]model.NasaAsteroidObject),
+		weather:   make(map[string]model.DailyWeather),
+		media:     make(map[string][]byte),
+	}
+}
+
+// WithAsteroids seeds the near-Earth objects approaching on date.
+func (f *NASA) WithAsteroids(date time.Time, objects ...model.NasaAsteroidObject) *NASA {
+	key := date.Format(dateLayout)
+	f.asteroids[key] = append(f.asteroids[key], objects...)
+	return f
+}
+
+// WithCatalogue seeds objects served by GetAsteroid and BrowseAsteroids,
+// typically with their orbital data and full approach history. Objects seeded
+// with WithAsteroids are served too.
+func (f *NASA) WithCatalogue(objects ...model.NasaAsteroidObject) *NASA {
+	f.catalogue = append(f.catalogue, objects...)
+	return f
+}
+
+func (f *NASA) WithEvents(events ...model.NasaEarthEvent) *NASA {
+	f.events = append(f.events, events...)
+	return f
+}
+
+// WithWeather seeds the POWER daily weather served for any position.
+func (f *NASA) WithWeather(days ...model.DailyWeather) *NASA {
+	for _, day := range days {
+		f.weather[day.Date.Format(dateLayout)] = day
+	}
+	return f
+}
+
+// WithAPOD seeds Astronomy Pictures of the Day, in date order.
+func (f *NASA) WithAPOD(apods ...model.APOD) *NASA {
+	f.apods = append(f.apods, apods...)
+	slices.SortStableFunc(f.apods, func(a, b model.APOD) int { return strings.Compare(a.Date, b.Date) })
+	return f
+}
+
+// WithMedia serves content for downloads of url.
+func (f *NASA) WithMedia(url string, content []byte) *NASA {
+	f.media[url] = content
+	return f
+}
+
+// WithQuota sets the api.nasa.gov quota reported by Quota.
+func (f *NASA) WithQuota(limit, remaining int) *NASA {
+	f.quota = api.RateLimitStatus{Known: true, Limit: limit, Remaining: remaining}
+	return f
+}
+
+// WithLatency delays every call by d, or until the call's context is done.
+func (f *NASA) WithLatency(d time.Duration) *NASA {
+	f.setLatency(d)
+	return f
+}
+
+// FailOn makes the named method, e.g. "GetAsteroids", return err.
+func (f *NASA) FailOn(method string, err error) *NASA {
+	f.failOn(method, err)
+	return f
+}
+
+// FailAll makes every method without a FailOn error return err.
+func (f *NASA) FailAll(err error) *NASA {
+	f.failAll(err)
+	return f
+}
+
+func (f *NASA) GetEarthEvents(ctx context.Context, query *api.EventQuery) ([]model.NasaEarthEvent, error) {
+	if err := f.call(ctx, "GetEarthEvents"); err != nil {
+		return []model.NasaEarthEvent{}, err
+	}
+	return filterEvents(query, f.events, time.Now())
+}
+
+// GetPointWeather returns one entry per day from start to end. Days without
+// seeded weather have nil values, like days POWER has not processed yet.
+func (f *NASA) GetPointWeather(ctx context.Context, latitude, longitude float64, start, end time.Time) ([]model.DailyWeather, error) {
+	if err := f.call(ctx, "GetPointWeather"); err != nil {
+		return nil, err
+	}
+	days := []model.DailyWeather{}
+	for day := start.UTC().Truncate(24 * time.Hour); !day.After(end); day = day.AddDate(0, 0, 1) {
+		weather, ok := f.weather[day.Format(dateLayout)]
+		if !ok {
+			weather = model.DailyWeather{Date: day}
+		}
+		days = append(days, weather)
+	}
+	return days, nil
+}
+
+func (f *NASA) GetAsteroids(ctx context.Context, queryParams string) (model.NasaAsteroid, error) {
+	if err := f.call(ctx, "GetAsteroids"); err != nil {
+		return model.NasaAsteroid{}, err
+	}
+	params, err := url.ParseQuery(strings.TrimPrefix(queryParams, "?"))
+	if err != nil {
+		return model.NasaAsteroid{}, fmt.Errorf("invalid query: %w", err)
+	}
+	start, err := time.Parse(dateLayout, params.Get("start_date"))
+	if err != nil {
+		return model.NasaAsteroid{}, fmt.Errorf("invalid start_date: %w", err)
+	}
+	end := start
+	if params.Has("end_date") {
+		if end, err = time.Parse(dateLayout, params.Get("end_date")); err != nil {
+			return model.NasaAsteroid{}, fmt.Errorf("invalid end_date: %w", err)
+		}
+	}
+
+	feed := model.NasaAsteroid{NearEarthObjects: make(map[string][]model.NasaAsteroidObject)}
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		key := day.Format(dateLayout)
+		objects := f.asteroids[key]
+		feed.NearEarthObjects[key] = append([]model.NasaAsteroidObject{}, objects...)
+		feed.ElementCount += len(objects)
+	}
+	return feed, nil
+}
+
+func (f *NASA) GetAPOD(ctx context.Context, query *api.APODQuery) ([]model.APOD, error) {
+	if err := f.call(ctx, "GetAPOD"); err != nil {
+		return nil, err
+	}
+	return filterAPODs(query, f.apods, time.Now().UTC())
+}
+
+// DownloadMedia returns a 404 *api.StatusError for URLs without seeded
+// content.
+func (f *NASA) DownloadMedia(ctx context.Context, mediaURL string, w io.Writer) (int64, error) {
+	if err := f.call(ctx, "DownloadMedia"); err != nil {
+		return 0, err
+	}
+	content, ok := f.media[mediaURL]
+	if !ok {
+		return 0, &api.StatusError{StatusCode: http.StatusNotFound, Attempts: 1}
+	}
+	n, err := w.Write(content)
+	return int64(n), err
+}
+
+// GetAsteroid returns a 404 *api.StatusError for unknown IDs, like NeoWs.
+func (f *NASA) GetAsteroid(ctx context.Context, id string) (model.NasaAsteroidObject, error) {
+	if err := f.call(ctx, "GetAsteroid"); err != nil {
+		return model.NasaAsteroidObject{}, err
+	}
+	for _, asteroid := range f.allAsteroids() {
+		if asteroid.ID == id || asteroid.NEOReferenceID == id {
+			return asteroid, nil
+		}
+	}
+	return model.NasaAsteroidObject{}, &api.StatusError{StatusCode: http.StatusNotFound, Body: `{"code":404,"http_error":"NOT_FOUND"}`, Attempts: 1}
+}
+
+func (f *NASA) BrowseAsteroids(ctx context.Context, page, size int) (model.NasaAsteroidBrowse, error) {
+	if err := f.call(ctx, "BrowseAsteroids"); err != nil {
+		return model.NasaAsteroidBrowse{}, err
+	}
+	if size <= 0 {
+		size = 20
+	}
+	all := f.allAsteroids()
+
+	var browse model.NasaAsteroidBrowse
+	browse.Page.Size = size
+	browse.Page.Number = page
+	browse.Page.TotalElements = len(all)
+	browse.Page.TotalPages = (len(all) + size - 1) / size
+	start := min(max(page, 0)*size, len(all))
+	browse.NearEarthObjects = all[start:min(start+size, len(all))]
+	return browse, nil
+}
+
+// allAsteroids lists every seeded object once, ordered by ID.
+func (f *NASA) allAsteroids() []model.NasaAsteroidObject {
+	seen := make(map[string]bool)
+	var all []model.NasaAsteroidObject
+	add := func(asteroid model.NasaAsteroidObject) {
+		if !seen[asteroid.ID] {
+			seen[asteroid.ID] = true
+			all = append(all, asteroid)
+		}
+	}
+	for _, asteroid := range f.catalogue {
+		add(asteroid)
+	}
+	for _, objects := range f.asteroids {
+		for _, asteroid := range objects {
+			add(asteroid)
+		}
+	}
+	slices.SortFunc(all, func(a, b model.NasaAsteroidObject) int { return strings.Compare(a.ID, b.ID) })
+	return all
+}
+
+func (f *NASA) Quota() api.RateLimitStatus {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.quota
+}
@@ -0,0 +1,407 @@
+package apitest
+
+import (
+	"cmp"
+	"context"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/MitiaRD/ReMarkable-cli/api"
+	"github.com/MitiaRD/ReMarkable-cli/model"
+)
+
+// SpaceX is an in-memory api.SpaceXAPI. Launch queries are filtered, sorted,
+// paginated and populated the way the real /launches/query endpoint does;
+// the other collections are filtered, sorted and paginated.
+type SpaceX struct {
+	behaviour
+	launches   []model.Launch
+	rockets    []model.Rocket
+	crew       []model.Crew
+	launchpads []model.Launchpad
+	payloads   []model.Payload
+	cores      []model.Core
+	capsules   []model.Capsule
+	ships      []model.Ship
+	landpads   []model.Landpad
+}
+
+var _ api.SpaceXAPI = (*SpaceX)(nil)
+
+func NewSpaceX() *SpaceX {
+	return &SpaceX{}
+}
+
+func (f *SpaceX) WithLaunches(launches ...model.Launch) *SpaceX {
+	f.launches = append(f.launches, launches...)
+	return f
+}
+
+func (f *SpaceX) WithRockets(rockets ...model.Rocket) *SpaceX {
+	f.rockets = append(f.rockets, rockets...)
+	return f
+}
+
+func (f *SpaceX) WithCrew(crew ...model.Crew) *SpaceX {
+	f.crew = append(f.crew, crew...)
+	return f
+}
+
+func (f *SpaceX) WithLaunchpads(launchpads ...model.Launchpad) *SpaceX {
+	f.launchpads = append(f.launchpads, launchpads...)
+	return f
+}
+
+func (f *SpaceX) WithPayloads(payloads ...model.Payload) *SpaceX {
+	f.payloads = append(f.payloads, payloads...)
+	return f
+}
+
+func (f *SpaceX) WithCores(cores ...model.Core) *SpaceX {
+	f.cores = append(f.cores, cores...)
+	return f
+}
+
+func (f *SpaceX) WithCapsules(capsules ...model.Capsule) *SpaceX {
+	f.capsules = append(f.capsules, capsules...)
+	return f
+}
+
+func (f *SpaceX) WithShips(ships ...model.Ship) *SpaceX {
+	f.ships = append(f.ships, ships...)
+	return f
+}
+
+func (f *SpaceX) WithLandpads(landpads ...model.Landpad) *SpaceX {
+	f.landpads = append(f.landpads, landpads...)
+	return f
+}
+
+// WithLatency delays every call by d, or until the call's context is done.
+func (f *SpaceX) WithLatency(d time.Duration) *SpaceX {
+	f.setLatency(d)
+	return f
+}
+
+// FailOn makes the named method, e.g. "QueryLaunches", return err.
+func (f *SpaceX) FailOn(method string, err error) *SpaceX {
+	f.failOn(method, err)
+	return f
+}
+
+// FailAll makes every method without a FailOn error return err.
+func (f *SpaceX) FailAll(err error) *SpaceX {
+	f.failAll(err)
+	return f
+}
+
+func (f *SpaceX) GetLaunchesWithQuery(ctx context.Context, query *api.LaunchQuery) ([]model.Launch, error) {
+	page, err := f.QueryLaunches(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	return page.Docs, nil
+}
+
+func (f *SpaceX) QueryLaunches(ctx context.Context, query *api.LaunchQuery) (api.Page[model.Launch], error) {
+	if err := f.call(ctx, "QueryLaunches"); err != nil {
+		return api.Page[model.Launch]{}, err
+	}
+	if query == nil {
+		query = api.NewLaunchQuery()
+	}
+
+	var matches []model.Launch
+	for _, launch := range f.launches {
+		if query.Matches(launch) {
+			matches = append(matches, launch)
+		}
+	}
+
+	options := query.Options()
+	api.SortLaunches(matches, options.Sort)
+	page := api.Paginate(matches, options)
+	for i := range page.Docs {
+		page.Docs[i] = f.populate(page.Docs[i], options.Populate)
+	}
+	return page, nil
+}
+
+func (f *SpaceX) GetAllRockets(ctx context.Context) (map[string]model.Rocket, error) {
+	if err := f.call(ctx, "GetAllRockets"); err != nil {
+		return nil, err
+	}
+	return byID(f.rockets, func(r model.Rocket) string { return r.ID }), nil
+}
+
+func (f *SpaceX) GetAllCrewMembers(ctx context.Context) (map[string]model.Crew, error) {
+	if err := f.call(ctx, "GetAllCrewMembers"); err != nil {
+		return nil, err
+	}
+	return byID(f.crew, func(c model.Crew) string { return c.ID }), nil
+}
+
+func (f *SpaceX) GetAllLaunchpads(ctx context.Context) (map[string]model.Launchpad, error) {
+	if err := f.call(ctx, "GetAllLaunchpads"); err != nil {
+		return nil, err
+	}
+	return byID(f.launchpads, func(p model.Launchpad) string { return p.ID }), nil
+}
+
+func (f *SpaceX) GetAllPayloads(ctx context.Context) (map[string]model.Payload, error) {
+	if err := f.call(ctx, "GetAllPayloads"); err != nil {
+		return nil, err
+	}
+	return byID(f.payloads, payloadID), nil
+}
+
+func (f *SpaceX) GetPayload(ctx context.Context, id string) (model.Payload, error) {
+	if err := f.call(ctx, "GetPayload"); err != nil {
+		return model.Payload{}, err
+	}
+	return findByID(f.payloads, id, payloadID)
+}
+
+func (f *SpaceX) QueryPayloads(ctx context.Context, query *api.CollectionQuery) (api.Page[model.Payload], error) {
+	if err := f.call(ctx, "QueryPayloads"); err != nil {
+		return api.Page[model.Payload]{}, err
+	}
+	return queryDocs(f.payloads, query)
+}
+
+func (f *SpaceX) GetAllCores(ctx context.Context) (map[string]model.Core, error) {
+	if err := f.call(ctx, "GetAllCores"); err != nil {
+		return nil, err
+	}
+	return byID(f.cores, coreID), nil
+}
+
+func (f *SpaceX) GetCore(ctx context.Context, id string) (model.Core, error) {
+	if err := f.call(ctx, "GetCore"); err != nil {
+		return model.Core{}, err
+	}
+	return findByID(f.cores, id, coreID)
+}
+
+func (f *SpaceX) QueryCores(ctx context.Context, query *api.CollectionQuery) (api.Page[model.Core], error) {
+	if err := f.call(ctx, "QueryCores"); err != nil {
+		return api.Page[model.Core]{}, err
+	}
+	return queryDocs(f.cores, query)
+}
+
+func (f *SpaceX) GetAllCapsules(ctx context.Context) (map[string]model.Capsule, error) {
+	if err := f.call(ctx, "GetAllCapsules"); err != nil {
+		return nil, err
+	}
+	return byID(f.capsules, capsuleID), nil
+}
+
+func (f *SpaceX) GetCapsule(ctx context.Context, id string) (model.Capsule, error) {
+	if err := f.call(ctx, "GetCapsule"); err != nil {
+		return model.Capsule{}, err
+	}
+	return findByID(f.capsules, id, capsuleID)
+}
+
+func (f *SpaceX) QueryCapsules(ctx context.Context, query *api.CollectionQuery) (api.Page[model.Capsule], error) {
+	if err := f.call(ctx, "QueryCapsules"); err != nil {
+		return api.Page[model.Capsule]{}, err
+	}
+	return queryDocs(f.capsules, query)
+}
+
+func (f *SpaceX) GetAllShips(ctx context.Context) (map[string]model.Ship, error) {
+	if err := f.call(ctx, "GetAllShips"); err != nil {
+		return nil, err
+	}
+	return byID(f.ships, shipID), nil
+}
+
+func (f *SpaceX) GetShip(ctx context.Context, id string) (model.Ship, error) {
+	if err := f.call(ctx, "GetShip"); err != nil {
+		return model.Ship{}, err
+	}
+	return findByID(f.ships, id, shipID)
+}
+
+func (f *SpaceX) QueryShips(ctx context.Context, query *api.CollectionQuery) (api.Page[model.Ship], error) {
+	if err := f.call(ctx, "QueryShips"); err != nil {
+		return api.Page[model.Ship]{}, err
+	}
+	return queryDocs(f.ships, query)
+}
+
+func (f *SpaceX) GetAllLandpads(ctx context.Context) (map[string]model.Landpad, error) {
+	if err := f.call(ctx, "GetAllLandpads"); err != nil {
+		return nil, err
+	}
+	return byID(f.landpads, landpadID), nil
+}
+
+func (f *SpaceX) GetLandpad(ctx context.Context, id string) (model.Landpad, error) {
+	if err := f.call(ctx, "GetLandpad"); err != nil {
+		return model.Landpad{}, err
+	}
+	return findByID(f.landpads, id, landpadID)
+}
+
+func (f *SpaceX) QueryLandpads(ctx context.Context, query *api.CollectionQuery) (api.Page[model.Landpad], error) {
+	if err := f.call(ctx, "QueryLandpads"); err != nil {
+		return api.Page[model.Landpad]{}, err
+	}
+	return queryDocs(f.landpads, query)
+}
+
+func payloadID(p model.Payload) string { return p.ID }
+func coreID(c model.Core) string       { return c.ID }
+func capsuleID(c model.Capsule) string { return c.ID }
+func shipID(s model.Ship) string       { return s.ID }
+func landpadID(l model.Landpad) string { return l.ID }
+
+// populate embeds the seeded documents referenced by launch for each path.
+func (f *SpaceX) populate(launch model.Launch, paths []string) model.Launch {
+	for _, path := range paths {
+		switch path {
+		case "rocket":
+			if i := slices.IndexFunc(f.rockets, func(r model.Rocket) bool { return r.ID == launch.RocketId }); i >= 0 {
+				rocket := f.rockets[i]
+				launch.Rocket = &rocket
+			}
+		case "launchpad":
+			if i := slices.IndexFunc(f.launchpads, func(p model.Launchpad) bool { return p.ID == launch.LaunchpadId }); i >= 0 {
+				launchpad := f.launchpads[i]
+				launch.Launchpad = &launchpad
+			}
+		case "crew":
+			launch.CrewMembers = nil
+			for _, id := range launch.Crew {
+				if i := slices.IndexFunc(f.crew, func(c model.Crew) bool { return c.ID == id }); i >= 0 {
+					launch.CrewMembers = append(launch.CrewMembers, f.crew[i])
+				}
+			}
+		case "payloads":
+			launch.PayloadDetails = nil
+			for _, id := range launch.Payloads {
+				if i := slices.IndexFunc(f.payloads, func(p model.Payload) bool { return p.ID == id }); i >= 0 {
+					launch.PayloadDetails = append(launch.PayloadDetails, f.payloads[i])
+				}
+			}
+		case "cores.core", "cores.landpad":
+			launch.Cores = slices.Clone(launch.Cores)
+			for c := range launch.Cores {
+				core := &launch.Cores[c]
+				if path == "cores.core" {
+					if i := slices.IndexFunc(f.cores, func(seeded model.Core) bool { return seeded.ID == core.CoreID }); i >= 0 {
+						found := f.cores[i]
+						core.Core = &found
+					}
+				} else if i := slices.IndexFunc(f.landpads, func(p model.Landpad) bool { return p.ID == core.LandpadID }); i >= 0 {
+					landpad := f.landpads[i]
+					core.Landpad = &landpad
+				}
+			}
+		}
+	}
+	return launch
+}
+
+// queryDocs filters, sorts and paginates docs like a SpaceX /query endpoint.
+// Sort fields are compared by their JSON values.
+func queryDocs[T any](docs []T, query *api.CollectionQuery) (api.Page[T], error) {
+	if query == nil {
+		query = api.NewCollectionQuery()
+	}
+
+	type keyed struct {
+		doc    T
+		fields map[string]any
+	}
+	var matches []keyed
+	for _, doc := range docs {
+		ok, err := matchesCollection(query, doc)
+		if err != nil {
+			return api.Page[T]{}, err
+		}
+		if !ok {
+			continue
+		}
+		fields, err := jsonFields(doc)
+		if err != nil {
+			return api.Page[T]{}, err
+		}
+		matches = append(matches, keyed{doc: doc, fields: fields})
+	}
+
+	options := query.Options()
+	slices.SortStableFunc(matches, func(a, b keyed) int {
+		for _, field := range options.Sort {
+			c := compareJSON(a.fields[field.Field], b.fields[field.Field])
+			if field.Order == api.Descending {
+				c = -c
+			}
+			if c != 0 {
+				return c
+			}
+		}
+		return 0
+	})
+
+	sorted := make([]T, len(matches))
+	for i, match := range matches {
+		sorted[i] = match.doc
+	}
+	return api.Paginate(sorted, options), nil
+}
+
+// compareJSON orders decoded JSON scalars; null sorts first and values of
+// other types compare equal.
+func compareJSON(a, b any) int {
+	switch a := a.(type) {
+	case float64:
+		if b, ok := b.(float64); ok {
+			return cmp.Compare(a, b)
+		}
+	case string:
+		if b, ok := b.(string); ok {
+			return strings.Compare(a, b)
+		}
+	case bool:
+		if b, ok := b.(bool); ok && a != b {
+			if a {
+				return 1
+			}
+			return -1
+		}
+	case nil:
+		if b != nil {
+			return -1
+		}
+	}
+	if a != nil && b == nil {
+		return 1
+	}
+	return 0
+}
+
+// findByID returns the doc with the given ID, or a 404 *api.StatusError like
+// the SpaceX API.
+func findByID[T any](docs []T, id string, docID func(T) string) (T, error) {
+	for _, doc := range docs {
+		if docID(doc) == id {
+			return doc, nil
+		}
+	}
+	var zero T
+	return zero, &api.StatusError{StatusCode: http.StatusNotFound, Body: "Not Found", Attempts: 1}
+}
+
+func byID[T any](docs []T, id func(T) string) map[string]T {
+	result := make(map[string]T, len(docs))
+	for _, doc := range docs {
+		result[id(doc)] = doc
+	}
+	return result
+}
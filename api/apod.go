@@ -0,0 +1,94 @@
+package api
+
+import (
+	"bytes"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// APODQuery builds the query string for the APOD endpoint. The zero value
+// asks for today's picture. Date, Between and Count are alternatives; the
+// last one set wins.
+type APODQuery struct {
+	date   *time.Time
+	start  *time.Time
+	end    *time.Time
+	count  int
+	thumbs bool
+}
+
+func NewAPODQuery() *APODQuery {
+	return &APODQuery{}
+}
+
+// Date asks for the picture of a single day.
+func (q *APODQuery) Date(date time.Time) *APODQuery {
+	q.date, q.start, q.end, q.count = &date, nil, nil, 0
+	return q
+}
+
+// Between asks for every picture from start to end, inclusive. A zero end
+// means today.
+func (q *APODQuery) Between(start, end time.Time) *APODQuery {
+	q.date, q.start, q.end, q.count = nil, &start, nil, 0
+	if !end.IsZero() {
+		q.end = &end
+	}
+	return q
+}
+
+// Count asks for n randomly chosen pictures.
+func (q *APODQuery) Count(n int) *APODQuery {
+	q.date, q.start, q.end, q.count = nil, nil, nil, n
+	return q
+}
+
+// Thumbs includes thumbnail URLs for videos.
+func (q *APODQuery) Thumbs(thumbs bool) *APODQuery {
+	q.thumbs = thumbs
+	return q
+}
+
+// Encode returns the query string, including the leading "?", or "" for an
+// empty or nil query.
+func (q *APODQuery) Encode() string {
+	if q == nil {
+		return ""
+	}
+	values := url.Values{}
+	if q.date != nil {
+		values.Set("date", q.date.Format(time.DateOnly))
+	}
+	if q.start != nil {
+		values.Set("start_date", q.start.Format(time.DateOnly))
+	}
+	if q.end != nil {
+		values.Set("end_date", q.end.Format(time.DateOnly))
+	}
+	if q.count > 0 {
+		values.Set("count", strconv.Itoa(q.count))
+	}
+	if q.thumbs {
+		values.Set("thumbs", "true")
+	}
+	if len(values) == 0 {
+		return ""
+	}
+	return "?" + values.Encode()
+}
+
+// apodDecoder decodes APOD responses into a slice. The endpoint answers a
+// single date with one object and ranges or counts with an array.
+type apodDecoder struct{}
+
+func (apodDecoder) Decode(body []byte, v any) error {
+	if trimmed := bytes.TrimSpace(body); len(trimmed) > 0 && trimmed[0] == '{' {
+		body = append(append([]byte("["), trimmed...), ']')
+	}
+	return JSONDecoder{}.Decode(body, v)
+}
+
+// MaxMediaBytes caps the size of a downloaded media file. APOD's largest HD
+// images are a few tens of megabytes.
+const MaxMediaBytes = 200 << 20
@@ -0,0 +1,85 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPODQueryEncode(t *testing.T) {
+	day := time.Date(2024, 9, 26, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		query    *APODQuery
+		expected string
+	}{
+		{name: "today", query: NewAPODQuery(), expected: ""},
+		{name: "nil", query: nil, expected: ""},
+		{name: "date", query: NewAPODQuery().Date(day), expected: "?date=2024-09-26"},
+		{name: "range", query: NewAPODQuery().Between(day, day.AddDate(0, 0, 3)), expected: "?end_date=2024-09-29&start_date=2024-09-26"},
+		{name: "open range", query: NewAPODQuery().Between(day, time.Time{}), expected: "?start_date=2024-09-26"},
+		{name: "count with thumbs", query: NewAPODQuery().Count(3).Thumbs(true), expected: "?count=3&thumbs=true"},
+		{name: "last selection wins", query: NewAPODQuery().Count(3).Date(day), expected: "?date=2024-09-26"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.query.Encode())
+		})
+	}
+}
+
+func TestNASAClientAPOD(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/planetary/apod":
+			assert.Equal(t, "test-key", r.URL.Query().Get("api_key"))
+			if r.URL.Query().Has("date") {
+				io.WriteString(w, `{"date":"2024-09-26","title":"Single","media_type":"image","url":"`+"http://"+r.Host+`/image/apod.JPG"}`)
+				return
+			}
+			io.WriteString(w, `[{"date":"2024-09-26","title":"First"},{"date":"2024-09-27","title":"Second"}]`)
+		case "/image/apod.JPG":
+			assert.Empty(t, r.URL.Query().Get("api_key"), "media downloads do not carry the NASA key")
+			w.Write([]byte{0xff, 0xd8, 0xff})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	config := testConfig()
+	config.NASABaseURL = server.URL
+	config.NASAAPIKey = "test-key"
+	nasa := NewNASAClient(config, testLogger())
+	ctx := context.Background()
+	day := time.Date(2024, 9, 26, 0, 0, 0, 0, time.UTC)
+
+	single, err := nasa.GetAPOD(ctx, NewAPODQuery().Date(day))
+	require.NoError(t, err)
+	require.Len(t, single, 1)
+	assert.Equal(t, "Single", single[0].Title)
+
+	rangeAPODs, err := nasa.GetAPOD(ctx, NewAPODQuery().Between(day, day.AddDate(0, 0, 1)))
+	require.NoError(t, err)
+	assert.Len(t, rangeAPODs, 2)
+
+	var media bytes.Buffer
+	n, err := nasa.DownloadMedia(ctx, single[0].URL, &media)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), n)
+	assert.Equal(t, []byte{0xff, 0xd8, 0xff}, media.Bytes())
+
+	_, err = nasa.DownloadMedia(ctx, server.URL+"/image/missing.jpg", io.Discard)
+	var statusErr *StatusError
+	require.ErrorAs(t, err, &statusErr)
+	assert.Equal(t, http.StatusNotFound, statusErr.StatusCode)
+}
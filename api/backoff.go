@@ -0,0 +1,92 @@
+package api
+
+import (
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	BackoffLinear             = "linear"
+	BackoffFullJitter         = "full-jitter"
+	BackoffDecorrelatedJitter = "decorrelated-jitter"
+)
+
+// NewBackoff returns the named strategy, defaulting to linear backoff.
+func NewBackoff(name string, base, max time.Duration) BackoffStrategy {
+	switch name {
+	case BackoffFullJitter:
+		return FullJitterBackoff{BaseDelay: base, MaxDelay: max}
+	case BackoffDecorrelatedJitter:
+		return DecorrelatedJitterBackoff{BaseDelay: base, MaxDelay: max}
+	default:
+		return LinearBackoff{BaseDelay: base, MaxDelay: max}
+	}
+}
+
+// LinearBackoff grows the delay linearly with the attempt number, with ±25%
+// jitter, capped at MaxDelay.
+type LinearBackoff struct {
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+}
+
+func (b LinearBackoff) Delay(attempt int, _ time.Duration) time.Duration {
+	delay := float64(b.BaseDelay) * float64(attempt+1)
+
+	jitter := delay * 0.25 * (rand.Float64()*2 - 1)
+	delay += jitter
+
+	if delay > float64(b.MaxDelay) {
+		delay = float64(b.MaxDelay)
+	}
+
+	return time.Duration(delay)
+}
+
+// FullJitterBackoff picks a random delay between zero and the exponential
+// ceiling BaseDelay * 2^attempt, capped at MaxDelay.
+type FullJitterBackoff struct {
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+}
+
+func (b FullJitterBackoff) Delay(attempt int, _ time.Duration) time.Duration {
+	ceiling := math.Min(float64(b.MaxDelay), float64(b.BaseDelay)*math.Pow(2, float64(attempt)))
+	return time.Duration(rand.Float64() * ceiling)
+}
+
+// DecorrelatedJitterBackoff picks a random delay between BaseDelay and three
+// times the previous delay, capped at MaxDelay.
+type DecorrelatedJitterBackoff struct {
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+}
+
+func (b DecorrelatedJitterBackoff) Delay(_ int, previous time.Duration) time.Duration {
+	upper := math.Max(float64(b.BaseDelay), 3*float64(previous))
+	delay := float64(b.BaseDelay) + rand.Float64()*(upper-float64(b.BaseDelay))
+	return time.Duration(math.Min(float64(b.MaxDelay), delay))
+}
+
+// ParseRetryAfter reads a Retry-After header value in either of its RFC 9110
+// forms: delay-seconds or an HTTP-date. Dates in the past yield zero.
+func ParseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(date.Sub(now), 0), true
+	}
+	return 0, false
+}
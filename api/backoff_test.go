@@ -0,0 +1,131 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		value    string
+		expected time.Duration
+		ok       bool
+	}{
+		{name: "empty", value: ""},
+		{name: "seconds", value: "120", expected: 2 * time.Minute, ok: true},
+		{name: "zero seconds", value: "0", ok: true},
+		{name: "negative seconds", value: "-5"},
+		{name: "fractional seconds", value: "1.5"},
+		{name: "http date", value: "Mon, 01 Jan 2024 12:00:30 GMT", expected: 30 * time.Second, ok: true},
+		{name: "rfc850 date", value: "Monday, 01-Jan-24 12:01:00 GMT", expected: time.Minute, ok: true},
+		{name: "date in the past", value: "Mon, 01 Jan 2024 11:00:00 GMT", ok: true},
+		{name: "garbage", value: "soon"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			delay, ok := ParseRetryAfter(tt.value, now)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, delay)
+		})
+	}
+}
+
+func TestBackoffStrategiesStayInBounds(t *testing.T) {
+	base, max := 100*time.Millisecond, time.Second
+
+	for _, name := range []string{BackoffLinear, BackoffFullJitter, BackoffDecorrelatedJitter} {
+		t.Run(name, func(t *testing.T) {
+			backoff := NewBackoff(name, base, max)
+			var delay time.Duration
+			for attempt := 0; attempt < 20; attempt++ {
+				delay = backoff.Delay(attempt, delay)
+				assert.GreaterOrEqual(t, delay, time.Duration(0))
+				assert.LessOrEqual(t, delay, max)
+			}
+		})
+	}
+
+	decorrelated := DecorrelatedJitterBackoff{BaseDelay: base, MaxDelay: max}
+	for i := 0; i < 100; i++ {
+		delay := decorrelated.Delay(1, 200*time.Millisecond)
+		assert.GreaterOrEqual(t, delay, base)
+		assert.LessOrEqual(t, delay, 600*time.Millisecond)
+	}
+}
+
+func TestRequesterCapsRetryAfter(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.Header().Set("Retry-After", time.Now().Add(time.Hour).UTC().Format(http.TimeFormat))
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	config := testConfig()
+	config.MaxDelay = 20 * time.Millisecond
+	requester := NewRequester(config, testLogger())
+
+	start := time.Now()
+	_, err := getJSON[map[string]any](context.Background(), requester, server.URL, "")
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestRequesterStopsRetryingAtDeadline(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Retry-After", "2")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	config := testConfig()
+	config.MaxDelay = 5 * time.Second
+	requester := NewRequester(config, testLogger())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := getJSON[map[string]any](ctx, requester, server.URL, "")
+	var statusErr *StatusError
+	require.ErrorAs(t, err, &statusErr)
+	assert.Equal(t, http.StatusServiceUnavailable, statusErr.StatusCode)
+	assert.Equal(t, 1, calls)
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+}
+
+func TestRequesterWaitAbortsOnCancel(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	config := testConfig()
+	config.BaseDelay = time.Hour
+	config.MaxDelay = time.Hour
+	requester := NewRequester(config, testLogger())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	_, err := getJSON[map[string]any](ctx, requester, server.URL, "")
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,132 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen matches every *CircuitOpenError via errors.Is.
+var ErrCircuitOpen = errors.New("circuit open")
+
+// CircuitOpenError is returned without touching the network while the
+// breaker for Host is open.
+type CircuitOpenError struct {
+	Host    string
+	RetryAt time.Time
+}
+
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("circuit open for %s: upstream unavailable, retrying after %s", e.Host, e.RetryAt.Format(time.TimeOnly))
+}
+
+func (e *CircuitOpenError) Is(target error) bool {
+	return target == ErrCircuitOpen
+}
+
+type breakerState int
+
+const (
+	breakerClosed breakerState = iota
+	breakerOpen
+	breakerHalfOpen
+)
+
+// CircuitBreaker trips after threshold consecutive failures and rejects
+// calls until cooldown has passed. It then lets a single probe through:
+// success closes the breaker again, failure reopens it.
+type CircuitBreaker struct {
+	mu        sync.Mutex
+	host      string
+	threshold int
+	cooldown  time.Duration
+	state     breakerState
+	failures  int
+	openedAt  time.Time
+	probing   bool
+	now       func() time.Time
+}
+
+// Allow reports whether a call may proceed.
+func (b *CircuitBreaker) Allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case breakerOpen:
+		if b.now().Before(b.openedAt.Add(b.cooldown)) {
+			return &CircuitOpenError{Host: b.host, RetryAt: b.openedAt.Add(b.cooldown)}
+		}
+		b.state = breakerHalfOpen
+		b.probing = true
+		return nil
+	case breakerHalfOpen:
+		if b.probing {
+			return &CircuitOpenError{Host: b.host, RetryAt: b.now()}
+		}
+		b.probing = true
+	}
+	return nil
+}
+
+func (b *CircuitBreaker) Success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.state = breakerClosed
+	b.failures = 0
+	b.probing = false
+}
+
+func (b *CircuitBreaker) Failure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	b.probing = false
+	if b.state == breakerHalfOpen || b.failures >= b.threshold {
+		b.state = breakerOpen
+		b.openedAt = b.now()
+	}
+}
+
+// Release gives up a half-open probe slot without recording an outcome.
+func (b *CircuitBreaker) Release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+}
+
+// Breakers holds one CircuitBreaker per upstream host.
+type Breakers struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	byHost    map[string]*CircuitBreaker
+}
+
+func NewBreakers(threshold int, cooldown time.Duration) *Breakers {
+	return &Breakers{
+		threshold: threshold,
+		cooldown:  cooldown,
+		byHost:    make(map[string]*CircuitBreaker),
+	}
+}
+
+func (b *Breakers) For(host string) *CircuitBreaker {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	breaker, ok := b.byHost[host]
+	if !ok {
+		breaker = &CircuitBreaker{
+			host:      host,
+			threshold: b.threshold,
+			cooldown:  b.cooldown,
+			now:       time.Now,
+		}
+		b.byHost[host] = breaker
+	}
+	return breaker
+}
@@ -0,0 +1,66 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCircuitBreakerStates(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	breaker := NewBreakers(2, time.Minute).For("eonet.gsfc.nasa.gov")
+	breaker.now = func() time.Time { return now }
+
+	require.NoError(t, breaker.Allow())
+	breaker.Failure()
+	require.NoError(t, breaker.Allow())
+	breaker.Failure()
+
+	err := breaker.Allow()
+	var openErr *CircuitOpenError
+	require.ErrorAs(t, err, &openErr)
+	assert.Equal(t, "eonet.gsfc.nasa.gov", openErr.Host)
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+
+	// After the cooldown a single probe goes through.
+	now = now.Add(time.Minute)
+	require.NoError(t, breaker.Allow())
+	assert.ErrorIs(t, breaker.Allow(), ErrCircuitOpen)
+
+	// A failed probe reopens the breaker; a successful one closes it.
+	breaker.Failure()
+	assert.ErrorIs(t, breaker.Allow(), ErrCircuitOpen)
+	now = now.Add(time.Minute)
+	require.NoError(t, breaker.Allow())
+	breaker.Success()
+	require.NoError(t, breaker.Allow())
+	require.NoError(t, breaker.Allow())
+}
+
+func TestRequesterShortCircuitsFailingHost(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	config := testConfig()
+	config.EONETBaseURL = server.URL
+	config.Retries = 10
+	config.BreakerThreshold = 3
+	client := NewNASAClient(config, testLogger())
+
+	_, err := client.GetEarthEvents(context.Background(), NewEventQuery())
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+	assert.Equal(t, 3, calls)
+
+	_, err = client.GetEarthEvents(context.Background(), NewEventQuery())
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+	assert.Equal(t, 3, calls)
+}
@@ -0,0 +1,255 @@
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// CacheClass groups cached responses that share a TTL.
+type CacheClass string
+
+const (
+	// CacheReference covers rockets, crew and launchpads, which rarely change.
+	CacheReference CacheClass = "reference"
+	// CacheLaunches covers launch queries.
+	CacheLaunches CacheClass = "launches"
+	// CacheNASA covers NASA feeds such as NeoWs and EONET.
+	CacheNASA CacheClass = "nasa"
+)
+
+const cacheFileExt = ".json"
+
+// cacheTempPattern names the files Put writes before renaming them into
+// place.
+const cacheTempPattern = "tmp-*"
+
+// CacheEntry is a single stored response body.
+type CacheEntry struct {
+	Key      string     `json:"key"`
+	Class    CacheClass `json:"class"`
+	Method   string     `json:"method"`
+	URL      string     `json:"url"`
+	StoredAt time.Time  `json:"stored_at"`
+	Body     []byte     `json:"body"`
+
+	// Validators returned with the body, used to revalidate stale entries.
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"last_modified,omitempty"`
+}
+
+// Revalidatable reports whether the entry carries a validator the server can
+// answer with 304 Not Modified.
+func (e *CacheEntry) Revalidatable() bool {
+	return e.ETag != "" || e.LastModified != ""
+}
+
+// CacheStats summarises the contents of the cache directory.
+type CacheStats struct {
+	Dir     string
+	Entries int
+	Expired int
+	Bytes   int64
+	ByClass map[CacheClass]int
+}
+
+// Cache is a persistent response cache stored as one JSON file per entry.
+type Cache struct {
+	dir  string
+	ttls map[CacheClass]time.Duration
+	now  func() time.Time
+}
+
+func NewCache(dir string, ttls map[CacheClass]time.Duration) (*Cache, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	return &Cache{
+		dir:  dir,
+		ttls: ttls,
+		now:  time.Now,
+	}, nil
+}
+
+// DefaultCacheDir returns the space-cli directory inside the user cache dir.
+func DefaultCacheDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "space-cli"), nil
+}
+
+// CacheKey identifies a request by method, URL and body.
+func CacheKey(method, url string, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(method))
+	hash.Write([]byte{0})
+	hash.Write([]byte(url))
+	hash.Write([]byte{0})
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+func (c *Cache) Dir() string {
+	return c.dir
+}
+
+// TTL returns how long entries of the given class stay fresh.
+func (c *Cache) TTL(class CacheClass) time.Duration {
+	return c.ttls[class]
+}
+
+// Fresh reports whether entry is still within its class TTL.
+func (c *Cache) Fresh(entry *CacheEntry) bool {
+	return c.now().Before(entry.StoredAt.Add(c.TTL(entry.Class)))
+}
+
+// Get returns the stored entry for key, fresh or not. A missing entry
+// returns fs.ErrNotExist.
+func (c *Cache) Get(key string) (*CacheEntry, error) {
+	data, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return nil, err
+	}
+	var entry CacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, fmt.Errorf("corrupt cache entry %s: %w", key, err)
+	}
+	return &entry, nil
+}
+
+func (c *Cache) Put(entry *CacheEntry) error {
+	if entry.StoredAt.IsZero() {
+		entry.StoredAt = c.now()
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(c.dir, cacheTempPattern)
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Rename(tmp.Name(), c.path(entry.Key)); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return nil
+}
+
+// Entries lists every stored entry, most recently stored first.
+func (c *Cache) Entries() ([]CacheEntry, error) {
+	files, err := c.files()
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]CacheEntry, 0, len(files))
+	for _, file := range files {
+		entry, err := c.Get(strings.TrimSuffix(file.Name(), cacheFileExt))
+		if err != nil {
+			continue
+		}
+		entries = append(entries, *entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].StoredAt.After(entries[j].StoredAt)
+	})
+	return entries, nil
+}
+
+func (c *Cache) Stats() (CacheStats, error) {
+	stats := CacheStats{
+		Dir:     c.dir,
+		ByClass: make(map[CacheClass]int),
+	}
+
+	files, err := c.files()
+	if err != nil {
+		return stats, err
+	}
+	for _, file := range files {
+		info, err := file.Info()
+		if err != nil {
+			continue
+		}
+		entry, err := c.Get(strings.TrimSuffix(file.Name(), cacheFileExt))
+		if err != nil {
+			continue
+		}
+		stats.Entries++
+		stats.Bytes += info.Size()
+		stats.ByClass[entry.Class]++
+		if !c.Fresh(entry) {
+			stats.Expired++
+		}
+	}
+	return stats, nil
+}
+
+// Clear removes every entry and returns how many were deleted. Temporary
+// files left behind by an interrupted Put are removed too, uncounted.
+func (c *Cache) Clear() (int, error) {
+	files, err := c.files()
+	if err != nil {
+		return 0, err
+	}
+	removed := 0
+	for _, file := range files {
+		if err := os.Remove(filepath.Join(c.dir, file.Name())); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return removed, err
+		}
+		removed++
+	}
+
+	temps, err := filepath.Glob(filepath.Join(c.dir, cacheTempPattern))
+	if err != nil {
+		return removed, err
+	}
+	for _, temp := range temps {
+		if err := os.Remove(temp); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return removed, err
+		}
+	}
+	return removed, nil
+}
+
+func (c *Cache) files() ([]fs.DirEntry, error) {
+	all, err := os.ReadDir(c.dir)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	files := all[:0]
+	for _, file := range all {
+		if !file.IsDir() && strings.HasSuffix(file.Name(), cacheFileExt) {
+			files = append(files, file)
+		}
+	}
+	return files, nil
+}
+
+func (c *Cache) path(key string) string {
+	return filepath.Join(c.dir, key+cacheFileExt)
+}
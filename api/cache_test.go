@@ -0,0 +1,157 @@
+package api
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/MitiaRD/ReMarkable-cli/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequesterServesFreshEntriesFromCache(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		io.WriteString(w, `[{"id":"falcon9","name":"Falcon 9"}]`)
+	}))
+	defer server.Close()
+
+	cache, err := NewCache(t.TempDir(), map[CacheClass]time.Duration{CacheReference: time.Hour})
+	require.NoError(t, err)
+
+	requester := NewRequester(testConfig(), testLogger(), WithCache(cache, false))
+	for i := 0; i < 3; i++ {
+		rockets, err := getJSON[[]model.Rocket](context.Background(), requester, server.URL, CacheReference)
+		require.NoError(t, err)
+		assert.Equal(t, "Falcon 9", rockets[0].Name)
+	}
+	assert.Equal(t, 1, calls)
+
+	refreshing := NewRequester(testConfig(), testLogger(), WithCache(cache, true))
+	_, err = getJSON[[]model.Rocket](context.Background(), refreshing, server.URL, CacheReference)
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls)
+
+	stats, err := cache.Stats()
+	require.NoError(t, err)
+	assert.Equal(t, 1, stats.Entries)
+	assert.Equal(t, 1, stats.ByClass[CacheReference])
+}
+
+func TestCacheExpiresByClass(t *testing.T) {
+	dir := t.TempDir()
+	cache, err := NewCache(dir, map[CacheClass]time.Duration{
+		CacheReference: 24 * time.Hour,
+		CacheLaunches:  time.Hour,
+	})
+	require.NoError(t, err)
+
+	storedAt := time.Now().Add(-2 * time.Hour)
+	require.NoError(t, cache.Put(&CacheEntry{Key: "a", Class: CacheReference, StoredAt: storedAt}))
+	require.NoError(t, cache.Put(&CacheEntry{Key: "b", Class: CacheLaunches, StoredAt: storedAt}))
+
+	reference, err := cache.Get("a")
+	require.NoError(t, err)
+	assert.True(t, cache.Fresh(reference))
+
+	launches, err := cache.Get("b")
+	require.NoError(t, err)
+	assert.False(t, cache.Fresh(launches))
+
+	// A Put interrupted before its rename leaves a temporary file behind.
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "tmp-123"), []byte("{"), 0o644))
+
+	removed, err := cache.Clear()
+	require.NoError(t, err)
+	assert.Equal(t, 2, removed)
+
+	entries, err := cache.Entries()
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, files)
+}
+
+func TestRequesterRevalidatesStaleEntries(t *testing.T) {
+	var fullResponses, notModified int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` || r.Header.Get("If-Modified-Since") == "Mon, 01 Jan 2024 00:00:00 GMT" {
+			notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		fullResponses++
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Last-Modified", "Mon, 01 Jan 2024 00:00:00 GMT")
+		io.WriteString(w, `{"docs":[{"name":"Crew-9"}]}`)
+	}))
+	defer server.Close()
+
+	// A zero TTL makes every stored entry stale, forcing revalidation.
+	cache, err := NewCache(t.TempDir(), map[CacheClass]time.Duration{CacheLaunches: 0})
+	require.NoError(t, err)
+	requester := NewRequester(testConfig(), testLogger(), WithCache(cache, false))
+
+	for i := 0; i < 3; i++ {
+		result, err := postJSON[struct {
+			Docs []model.Launch `json:"docs"`
+		}](context.Background(), requester, server.URL, map[string]interface{}{"query": map[string]interface{}{}}, CacheLaunches)
+		require.NoError(t, err)
+		require.Len(t, result.Docs, 1)
+		assert.Equal(t, "Crew-9", result.Docs[0].Name)
+	}
+
+	assert.Equal(t, 1, fullResponses)
+	assert.Equal(t, 2, notModified)
+
+	entries, err := cache.Entries()
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, `"v1"`, entries[0].ETag)
+}
+
+func TestRequesterTakesValidatorsFromNotModified(t *testing.T) {
+	var conditional []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if etag := r.Header.Get("If-None-Match"); etag != "" {
+			conditional = append(conditional, etag)
+			// The resource is unchanged but the server rotated its ETag.
+			w.Header().Set("ETag", `"v2"`)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		io.WriteString(w, `[{"id":"falcon9","name":"Falcon 9"}]`)
+	}))
+	defer server.Close()
+
+	cache, err := NewCache(t.TempDir(), map[CacheClass]time.Duration{CacheReference: 0})
+	require.NoError(t, err)
+	requester := NewRequester(testConfig(), testLogger(), WithCache(cache, false))
+	for i := 0; i < 3; i++ {
+		rockets, err := getJSON[[]model.Rocket](context.Background(), requester, server.URL, CacheReference)
+		require.NoError(t, err)
+		assert.Equal(t, "Falcon 9", rockets[0].Name)
+	}
+	assert.Equal(t, []string{`"v1"`, `"v2"`}, conditional)
+
+	entries, err := cache.Entries()
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, `"v2"`, entries[0].ETag)
+
+	// Refreshing sends no validators, so it always gets the full body.
+	conditional = nil
+	refreshing := NewRequester(testConfig(), testLogger(), WithCache(cache, true))
+	_, err = getJSON[[]model.Rocket](context.Background(), refreshing, server.URL, CacheReference)
+	require.NoError(t, err)
+	assert.Empty(t, conditional)
+}
@@ -2,66 +2,164 @@ package api
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"io"
+	"iter"
 	"log/slog"
-	"math/rand"
+	"math"
 	"net/http"
+	neturl "net/url"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/MitiaRD/ReMarkable-cli/model"
 )
 
-type Client interface {
-	GetLaunchesWithQuery(ctx context.Context, query map[string]interface{}) ([]model.Launch, error)
+// SpaceXAPI is the subset of the SpaceX v4 API used by the CLI. The apitest
+// package provides an in-memory implementation.
+type SpaceXAPI interface {
+	GetLaunchesWithQuery(ctx context.Context, query *LaunchQuery) ([]model.Launch, error)
+	QueryLaunches(ctx context.Context, query *LaunchQuery) (Page[model.Launch], error)
 	GetAllRockets(ctx context.Context) (map[string]model.Rocket, error)
 	GetAllCrewMembers(ctx context.Context) (map[string]model.Crew, error)
 	GetAllLaunchpads(ctx context.Context) (map[string]model.Launchpad, error)
-	GetEarthEvents(ctx context.Context, queryParams string) ([]model.NasaEarthEvent, error)
+
+	GetAllPayloads(ctx context.Context) (map[string]model.Payload, error)
+	GetPayload(ctx context.Context, id string) (model.Payload, error)
+	QueryPayloads(ctx context.Context, query *CollectionQuery) (Page[model.Payload], error)
+	GetAllCores(ctx context.Context) (map[string]model.Core, error)
+	GetCore(ctx context.Context, id string) (model.Core, error)
+	QueryCores(ctx context.Context, query *CollectionQuery) (Page[model.Core], error)
+	GetAllCapsules(ctx context.Context) (map[string]model.Capsule, error)
+	GetCapsule(ctx context.Context, id string) (model.Capsule, error)
+	QueryCapsules(ctx context.Context, query *CollectionQuery) (Page[model.Capsule], error)
+	GetAllShips(ctx context.Context) (map[string]model.Ship, error)
+	GetShip(ctx context.Context, id string) (model.Ship, error)
+	QueryShips(ctx context.Context, query *CollectionQuery) (Page[model.Ship], error)
+	GetAllLandpads(ctx context.Context) (map[string]model.Landpad, error)
+	GetLandpad(ctx context.Context, id string) (model.Landpad, error)
+	QueryLandpads(ctx context.Context, query *CollectionQuery) (Page[model.Landpad], error)
+}
+
+// NASAAPI is the subset of the NASA APIs used by the CLI.
+type NASAAPI interface {
+	GetEarthEvents(ctx context.Context, query *EventQuery) ([]model.NasaEarthEvent, error)
+	GetPointWeather(ctx context.Context, latitude, longitude float64, start, end time.Time) ([]model.DailyWeather, error)
 	GetAsteroids(ctx context.Context, queryParams string) (model.NasaAsteroid, error)
+	GetAPOD(ctx context.Context, query *APODQuery) ([]model.APOD, error)
+	DownloadMedia(ctx context.Context, mediaURL string, w io.Writer) (int64, error)
+	GetAsteroid(ctx context.Context, id string) (model.NasaAsteroidObject, error)
+	BrowseAsteroids(ctx context.Context, page, size int) (model.NasaAsteroidBrowse, error)
+	Quota() RateLimitStatus
 }
 
+type Client interface {
+	SpaceXAPI
+	NASAAPI
+}
+
+var (
+	_ SpaceXAPI = (*SpaceXClient)(nil)
+	_ NASAAPI   = (*NASAClient)(nil)
+)
+
 type SpaceXClient struct {
-	httpClient *http.Client
-	logger     *slog.Logger
-	config     *model.Config
+	requester *Requester
+	logger    *slog.Logger
+	config    *model.Config
 }
 
+// NASAClient talks to api.nasa.gov, EONET and POWER. Each host has its own
+// requester so that they are rate limited independently. Media files, which
+// may live on any host, are downloaded without the API key.
 type NASAClient struct {
-	httpClient *http.Client
-	logger     *slog.Logger
-	config     *model.Config
+	requester *Requester
+	eonet     *Requester
+	power     *Requester
+	media     *Requester
+	limiter   *RateLimiter
+	logger    *slog.Logger
+	config    *model.Config
 }
 
-func NewSpaceXClient(config *model.Config, logger *slog.Logger) *SpaceXClient {
+func NewSpaceXClient(config *model.Config, logger *slog.Logger, opts ...RequesterOption) *SpaceXClient {
+	limiter := NewRateLimiter(config.SpaceXRateLimit, int(math.Ceil(config.SpaceXRateLimit)))
 	return &SpaceXClient{
-		httpClient: &http.Client{
-			Timeout: config.Timeout,
-		},
-		logger: logger,
-		config: config,
+		requester: NewRequester(config, logger, append([]RequesterOption{WithRateLimiter(limiter)}, opts...)...),
+		logger:    logger,
+		config:    config,
 	}
 }
 
-func NewNASAClient(config *model.Config, logger *slog.Logger) *NASAClient {
+func NewNASAClient(config *model.Config, logger *slog.Logger, opts ...RequesterOption) *NASAClient {
+	limiter := NewRateLimiter(config.NASARateLimit, int(math.Ceil(config.NASARateLimit)))
+	eonetLimiter := NewRateLimiter(config.EONETRateLimit, int(math.Ceil(config.EONETRateLimit)))
+	powerLimiter := NewRateLimiter(config.POWERRateLimit, int(math.Ceil(config.POWERRateLimit)))
 	return &NASAClient{
-		httpClient: &http.Client{
-			Timeout: config.Timeout,
-		},
-		logger: logger,
-		config: config,
+		requester: NewRequester(config, logger, append([]RequesterOption{
+			WithRateLimiter(limiter),
+			WithAuth(APIKeyAuth{Param: "api_key", Key: config.NASAAPIKey}),
+		}, opts...)...),
+		eonet:   NewRequester(config, logger, append([]RequesterOption{WithRateLimiter(eonetLimiter)}, opts...)...),
+		power:   NewRequester(config, logger, append([]RequesterOption{WithRateLimiter(powerLimiter)}, opts...)...),
+		media:   NewRequester(config, logger, opts...),
+		limiter: limiter,
+		logger:  logger,
+		config:  config,
 	}
 }
 
-func (c *SpaceXClient) GetLaunchesWithQuery(ctx context.Context, query map[string]interface{}) ([]model.Launch, error) {
-	return c.fetchLaunchesWithQuery(ctx, "https://api.spacexdata.com/v4/launches/query", query)
+// Quota returns the api.nasa.gov quota reported by the most recent response.
+func (c *NASAClient) Quota() RateLimitStatus {
+	return c.limiter.Status()
+}
+
+// GetLaunchesWithQuery returns the first page of launches matching query.
+// Use IterateLaunches to walk every matching launch.
+func (c *SpaceXClient) GetLaunchesWithQuery(ctx context.Context, query *LaunchQuery) ([]model.Launch, error) {
+	page, err := c.QueryLaunches(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	if page.HasNextPage {
+		c.logger.Debug("launch query has more pages", "returned", len(page.Docs), "total", page.TotalDocs)
+	}
+	return page.Docs, nil
+}
+
+// QueryLaunches returns a single page of launches along with the pagination
+// metadata reported by the server.
+func (c *SpaceXClient) QueryLaunches(ctx context.Context, query *LaunchQuery) (Page[model.Launch], error) {
+	url := c.config.SpaceXBaseURL + "/launches/query"
+	return postJSON[Page[model.Launch]](ctx, c.requester, url, query, CacheLaunches)
+}
+
+// IterateLaunches yields every launch matching query, requesting further
+// pages as the caller consumes them. Iteration stops after the first error.
+func (c *SpaceXClient) IterateLaunches(ctx context.Context, query *LaunchQuery) iter.Seq2[model.Launch, error] {
+	return IterateLaunches(ctx, c, query)
+}
+
+// IterateLaunches walks every page of query using any SpaceXAPI or
+// LaunchProvider.
+func IterateLaunches(ctx context.Context, client LaunchQuerier, query *LaunchQuery) iter.Seq2[model.Launch, error] {
+	return iteratePages(ctx, query.Options(), func(ctx context.Context, options QueryOptions) (Page[model.Launch], error) {
+		return client.QueryLaunches(ctx, query.WithOptions(options))
+	})
+}
+
+// IterateCollection walks every page of query through fetchPage, e.g. a
+// client's QueryPayloads method.
+func IterateCollection[T any](ctx context.Context, query *CollectionQuery, fetchPage func(context.Context, *CollectionQuery) (Page[T], error)) iter.Seq2[T, error] {
+	return iteratePages(ctx, query.Options(), func(ctx context.Context, options QueryOptions) (Page[T], error) {
+		return fetchPage(ctx, query.WithOptions(options))
+	})
 }
 
 func (c *SpaceXClient) GetAllRockets(ctx context.Context) (map[string]model.Rocket, error) {
-	url := "https://api.spacexdata.com/v4/rockets"
-	rockets, err := fetchFromAPI[[]model.Rocket](c, ctx, url)
+	url := c.config.SpaceXBaseURL + "/rockets"
+	rockets, err := getJSON[[]model.Rocket](ctx, c.requester, url, CacheReference)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch rockets: %w", err)
 	}
@@ -74,8 +172,8 @@ func (c *SpaceXClient) GetAllRockets(ctx context.Context) (map[string]model.Rock
 }
 
 func (c *SpaceXClient) GetAllCrewMembers(ctx context.Context) (map[string]model.Crew, error) {
-	url := "https://api.spacexdata.com/v4/crew"
-	crew, err := fetchFromAPI[[]model.Crew](c, ctx, url)
+	url := c.config.SpaceXBaseURL + "/crew"
+	crew, err := getJSON[[]model.Crew](ctx, c.requester, url, CacheReference)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch crew members: %w", err)
 	}
@@ -88,8 +186,8 @@ func (c *SpaceXClient) GetAllCrewMembers(ctx context.Context) (map[string]model.
 }
 
 func (c *SpaceXClient) GetAllLaunchpads(ctx context.Context) (map[string]model.Launchpad, error) {
-	url := "https://api.spacexdata.com/v4/launchpads"
-	launchpads, err := fetchFromAPI[[]model.Launchpad](c, ctx, url)
+	url := c.config.SpaceXBaseURL + "/launchpads"
+	launchpads, err := getJSON[[]model.Launchpad](ctx, c.requester, url, CacheReference)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch launchpads: %w", err)
 	}
@@ -101,320 +199,189 @@ func (c *SpaceXClient) GetAllLaunchpads(ctx context.Context) (map[string]model.L
 	return launchpadMap, nil
 }
 
-func (c *NASAClient) GetEarthEvents(ctx context.Context, queryParams string) ([]model.NasaEarthEvent, error) {
-	url := "https://eonet.gsfc.nasa.gov/api/v3/events" + queryParams
-	events, err := fetchFromAPINASA[model.NasaEarth](c, ctx, url)
+func (c *SpaceXClient) GetAllPayloads(ctx context.Context) (map[string]model.Payload, error) {
+	return getCollection(ctx, c, "payloads", func(p model.Payload) string { return p.ID })
+}
+
+func (c *SpaceXClient) GetPayload(ctx context.Context, id string) (model.Payload, error) {
+	return getDocument[model.Payload](ctx, c, "payloads", id)
+}
+
+func (c *SpaceXClient) QueryPayloads(ctx context.Context, query *CollectionQuery) (Page[model.Payload], error) {
+	return queryCollection[model.Payload](ctx, c, "payloads", query)
+}
+
+func (c *SpaceXClient) GetAllCores(ctx context.Context) (map[string]model.Core, error) {
+	return getCollection(ctx, c, "cores", func(core model.Core) string { return core.ID })
+}
+
+func (c *SpaceXClient) GetCore(ctx context.Context, id string) (model.Core, error) {
+	return getDocument[model.Core](ctx, c, "cores", id)
+}
+
+func (c *SpaceXClient) QueryCores(ctx context.Context, query *CollectionQuery) (Page[model.Core], error) {
+	return queryCollection[model.Core](ctx, c, "cores", query)
+}
+
+func (c *SpaceXClient) GetAllCapsules(ctx context.Context) (map[string]model.Capsule, error) {
+	return getCollection(ctx, c, "capsules", func(capsule model.Capsule) string { return capsule.ID })
+}
+
+func (c *SpaceXClient) GetCapsule(ctx context.Context, id string) (model.Capsule, error) {
+	return getDocument[model.Capsule](ctx, c, "capsules", id)
+}
+
+func (c *SpaceXClient) QueryCapsules(ctx context.Context, query *CollectionQuery) (Page[model.Capsule], error) {
+	return queryCollection[model.Capsule](ctx, c, "capsules", query)
+}
+
+func (c *SpaceXClient) GetAllShips(ctx context.Context) (map[string]model.Ship, error) {
+	return getCollection(ctx, c, "ships", func(ship model.Ship) string { return ship.ID })
+}
+
+func (c *SpaceXClient) GetShip(ctx context.Context, id string) (model.Ship, error) {
+	return getDocument[model.Ship](ctx, c, "ships", id)
+}
+
+func (c *SpaceXClient) QueryShips(ctx context.Context, query *CollectionQuery) (Page[model.Ship], error) {
+	return queryCollection[model.Ship](ctx, c, "ships", query)
+}
+
+func (c *SpaceXClient) GetAllLandpads(ctx context.Context) (map[string]model.Landpad, error) {
+	return getCollection(ctx, c, "landpads", func(landpad model.Landpad) string { return landpad.ID })
+}
+
+func (c *SpaceXClient) GetLandpad(ctx context.Context, id string) (model.Landpad, error) {
+	return getDocument[model.Landpad](ctx, c, "landpads", id)
+}
+
+func (c *SpaceXClient) QueryLandpads(ctx context.Context, query *CollectionQuery) (Page[model.Landpad], error) {
+	return queryCollection[model.Landpad](ctx, c, "landpads", query)
+}
+
+// getCollection fetches a whole SpaceX collection keyed by document ID.
+func getCollection[T any](ctx context.Context, c *SpaceXClient, collection string, id func(T) string) (map[string]T, error) {
+	docs, err := getJSON[[]T](ctx, c.requester, c.config.SpaceXBaseURL+"/"+collection, CacheReference)
 	if err != nil {
-		return []model.NasaEarthEvent{}, fmt.Errorf("failed to fetch Earth events: %w", err)
+		return nil, fmt.Errorf("failed to fetch %s: %w", collection, err)
 	}
-	return events.Events, nil
+	docMap := make(map[string]T, len(docs))
+	for _, doc := range docs {
+		docMap[id(doc)] = doc
+	}
+	return docMap, nil
 }
 
-func (c *NASAClient) GetAsteroids(ctx context.Context, queryParams string) (model.NasaAsteroid, error) {
-	url := "https://api.nasa.gov/neo/rest/v1/feed" + queryParams + "&api_key=" + c.config.NASAAPIKey
-	asteroids, err := fetchFromAPINASA[model.NasaAsteroid](c, ctx, url)
+func getDocument[T any](ctx context.Context, c *SpaceXClient, collection, id string) (T, error) {
+	url := c.config.SpaceXBaseURL + "/" + collection + "/" + neturl.PathEscape(id)
+	doc, err := getJSON[T](ctx, c.requester, url, CacheReference)
 	if err != nil {
-		return model.NasaAsteroid{}, fmt.Errorf("failed to fetch asteroid data: %w", err)
+		return doc, fmt.Errorf("failed to fetch %s %s: %w", collection, id, err)
 	}
-	return asteroids, nil
+	return doc, nil
 }
 
-func fetchFromAPI[T any](c *SpaceXClient, ctx context.Context, url string) (T, error) {
-	var result T
-
-	for attempt := 0; attempt <= c.config.Retries; attempt++ {
-		select {
-		case <-ctx.Done():
-			return result, ctx.Err()
-		default:
-		}
-
-		req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-		if err != nil {
-			if attempt == c.config.Retries {
-				return result, fmt.Errorf("failed to create request after %d attempts: %w", c.config.Retries+1, err)
-			}
-			delay := c.calculateBackoffDelay(attempt)
-			c.logger.Warn("request creation failed, retrying", "attempt", attempt+1, "delay", delay, "error", err)
-			time.Sleep(delay)
-			continue
-		}
-
-		resp, err := c.httpClient.Do(req)
-		if err != nil {
-			if attempt == c.config.Retries {
-				return result, fmt.Errorf("HTTP request failed after %d attempts: %w", c.config.Retries+1, err)
-			}
-			delay := c.calculateBackoffDelay(attempt)
-			c.logger.Warn("HTTP request failed, retrying", "attempt", attempt+1, "delay", delay, "error", err)
-			time.Sleep(delay)
-			continue
-		}
-		defer resp.Body.Close()
-
-		if resp.StatusCode == 429 || (resp.StatusCode >= 500 && resp.StatusCode < 600) {
-			if attempt == c.config.Retries {
-				body, _ := io.ReadAll(resp.Body)
-				return result, fmt.Errorf("API returned status %d after %d attempts: %s", resp.StatusCode, c.config.Retries+1, string(body))
-			}
-
-			retryAfter := resp.Header.Get("Retry-After")
-			var delay time.Duration
-			if retryAfter != "" {
-				if seconds, err := time.ParseDuration(retryAfter + "s"); err == nil {
-					delay = seconds
-				} else {
-					delay = c.calculateBackoffDelay(attempt)
-				}
-			} else {
-				delay = c.calculateBackoffDelay(attempt)
-			}
-
-			c.logger.Warn("rate limited or server error, retrying", "status", resp.StatusCode, "attempt", attempt+1, "delay", delay)
-			time.Sleep(delay)
-			continue
-		}
-
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			body, _ := io.ReadAll(resp.Body)
-			return result, fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(body))
-		}
-
-		body, err := io.ReadAll(resp.Body)
-		if err != nil {
-			if attempt == c.config.Retries {
-				return result, fmt.Errorf("failed to read response body after %d attempts: %w", c.config.Retries+1, err)
-			}
-			delay := c.calculateBackoffDelay(attempt)
-			c.logger.Warn("failed to read response, retrying", "attempt", attempt+1, "delay", delay, "error", err)
-			time.Sleep(delay)
-			continue
-		}
-
-		err = json.Unmarshal(body, &result)
-		if err != nil {
-			if attempt == c.config.Retries {
-				return result, fmt.Errorf("failed to parse JSON after %d attempts: %w. Response: %s", c.config.Retries+1, err, string(body[:min(200, len(body))]))
-			}
-			delay := c.calculateBackoffDelay(attempt)
-			c.logger.Warn("failed to parse JSON, retrying", "attempt", attempt+1, "delay", delay, "error", err)
-			time.Sleep(delay)
-			continue
-		}
-
-		return result, nil
+func queryCollection[T any](ctx context.Context, c *SpaceXClient, collection string, query *CollectionQuery) (Page[T], error) {
+	if query == nil {
+		query = NewCollectionQuery()
 	}
-
-	return result, fmt.Errorf("unexpected error: max retries exceeded")
-}
-
-func fetchFromAPINASA[T any](c *NASAClient, ctx context.Context, url string) (T, error) {
-	var result T
-
-	for attempt := 0; attempt <= c.config.Retries; attempt++ {
-		select {
-		case <-ctx.Done():
-			return result, ctx.Err()
-		default:
-		}
-
-		req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-		if err != nil {
-			if attempt == c.config.Retries {
-				return result, fmt.Errorf("failed to create request after %d attempts: %w", c.config.Retries+1, err)
-			}
-			delay := c.calculateBackoffDelay(attempt)
-			c.logger.Warn("request creation failed, retrying", "attempt", attempt+1, "delay", delay, "error", err)
-			time.Sleep(delay)
-			continue
-		}
-
-		resp, err := c.httpClient.Do(req)
-		if err != nil {
-			if attempt == c.config.Retries {
-				return result, fmt.Errorf("HTTP request failed after %d attempts: %w", c.config.Retries+1, err)
-			}
-			delay := c.calculateBackoffDelay(attempt)
-			c.logger.Warn("HTTP request failed, retrying", "attempt", attempt+1, "delay", delay, "error", err)
-			time.Sleep(delay)
-			continue
-		}
-		defer resp.Body.Close()
-
-		if resp.StatusCode == 429 || (resp.StatusCode >= 500 && resp.StatusCode < 600) {
-			if attempt == c.config.Retries {
-				body, _ := io.ReadAll(resp.Body)
-				return result, fmt.Errorf("API returned status %d after %d attempts: %s", resp.StatusCode, c.config.Retries+1, string(body))
-			}
-
-			retryAfter := resp.Header.Get("Retry-After")
-			var delay time.Duration
-			if retryAfter != "" {
-				if seconds, err := time.ParseDuration(retryAfter + "s"); err == nil {
-					delay = seconds
-				} else {
-					delay = c.calculateBackoffDelay(attempt)
-				}
-			} else {
-				delay = c.calculateBackoffDelay(attempt)
-			}
-
-			c.logger.Warn("rate limited or server error, retrying", "status", resp.StatusCode, "attempt", attempt+1, "delay", delay)
-			time.Sleep(delay)
-			continue
-		}
-
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			body, _ := io.ReadAll(resp.Body)
-			return result, fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(body))
-		}
-
-		body, err := io.ReadAll(resp.Body)
-		if err != nil {
-			if attempt == c.config.Retries {
-				return result, fmt.Errorf("failed to read response body after %d attempts: %w", c.config.Retries+1, err)
-			}
-			delay := c.calculateBackoffDelay(attempt)
-			c.logger.Warn("failed to read response, retrying", "attempt", attempt+1, "delay", delay, "error", err)
-			time.Sleep(delay)
-			continue
-		}
-
-		err = json.Unmarshal(body, &result)
-		if err != nil {
-			if attempt == c.config.Retries {
-				return result, fmt.Errorf("failed to parse JSON after %d attempts: %w. Response: %s", c.config.Retries+1, err, string(body[:min(200, len(body))]))
-			}
-			delay := c.calculateBackoffDelay(attempt)
-			c.logger.Warn("failed to parse JSON, retrying", "attempt", attempt+1, "delay", delay, "error", err)
-			time.Sleep(delay)
-			continue
-		}
-
-		return result, nil
+	url := c.config.SpaceXBaseURL + "/" + collection + "/query"
+	page, err := postJSON[Page[T]](ctx, c.requester, url, query, CacheReference)
+	if err != nil {
+		return page, fmt.Errorf("failed to query %s: %w", collection, err)
 	}
-
-	return result, fmt.Errorf("unexpected error: max retries exceeded")
+	return page, nil
 }
 
-func (c *SpaceXClient) fetchLaunchesWithQuery(ctx context.Context, url string, query map[string]interface{}) ([]model.Launch, error) {
-	jsonData, err := json.Marshal(query)
+func (c *NASAClient) GetEarthEvents(ctx context.Context, query *EventQuery) ([]model.NasaEarthEvent, error) {
+	url := c.config.EONETBaseURL + "/events" + query.Encode()
+	events, err := getJSON[model.NasaEarth](ctx, c.eonet, url, CacheNASA)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal query: %w", err)
+		return []model.NasaEarthEvent{}, fmt.Errorf("failed to fetch Earth events: %w", err)
 	}
+	return events.Events, nil
+}
 
-	for attempt := 0; attempt <= c.config.Retries; attempt++ {
-		select {
-		case <-ctx.Done():
-			return nil, ctx.Err()
-		default:
-		}
-
-		req, err := http.NewRequestWithContext(ctx, "POST", url, strings.NewReader(string(jsonData)))
-		if err != nil {
-			return nil, fmt.Errorf("failed to create request: %w", err)
-		}
-
-		req.Header.Set("Content-Type", "application/json")
-
-		resp, err := c.httpClient.Do(req)
-		if err != nil {
-			if attempt == c.config.Retries {
-				return nil, fmt.Errorf("HTTP request failed after %d attempts: %w", c.config.Retries+1, err)
-			}
-			delay := c.calculateBackoffDelay(attempt)
-			c.logger.Warn("HTTP request failed, retrying", "attempt", attempt+1, "delay", delay, "error", err)
-			time.Sleep(delay)
-			continue
-		}
-		defer resp.Body.Close()
-
-		if resp.StatusCode == 429 || (resp.StatusCode >= 500 && resp.StatusCode < 600) {
-			if attempt == c.config.Retries {
-				body, _ := io.ReadAll(resp.Body)
-				return nil, fmt.Errorf("API returned status %d after %d attempts: %s", resp.StatusCode, c.config.Retries+1, string(body))
-			}
-
-			retryAfter := resp.Header.Get("Retry-After")
-			var delay time.Duration
-			if retryAfter != "" {
-				if seconds, err := time.ParseDuration(retryAfter + "s"); err == nil {
-					delay = seconds
-				} else {
-					delay = c.calculateBackoffDelay(attempt)
-				}
-			} else {
-				delay = c.calculateBackoffDelay(attempt)
-			}
-
-			c.logger.Warn("rate limited or server error, retrying", "status", resp.StatusCode, "attempt", attempt+1, "delay", delay)
-			time.Sleep(delay)
-			continue
-		}
-
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			body, _ := io.ReadAll(resp.Body)
-			return nil, fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(body))
-		}
-
-		body, err := io.ReadAll(resp.Body)
-		if err != nil {
-			if attempt == c.config.Retries {
-				return nil, fmt.Errorf("failed to read response body after %d attempts: %w", c.config.Retries+1, err)
-			}
-			delay := c.calculateBackoffDelay(attempt)
-			c.logger.Warn("failed to read response, retrying", "attempt", attempt+1, "delay", delay, "error", err)
-			time.Sleep(delay)
-			continue
-		}
-
-		var result struct {
-			Docs []model.Launch `json:"docs"`
-		}
-
-		err = json.Unmarshal(body, &result)
-		if err != nil {
-			if attempt == c.config.Retries {
-				return nil, fmt.Errorf("failed to parse JSON after %d attempts: %w. Response: %s", c.config.Retries+1, err, string(body[:min(200, len(body))]))
-			}
-			delay := c.calculateBackoffDelay(attempt)
-			c.logger.Warn("failed to parse JSON, retrying", "attempt", attempt+1, "delay", delay, "error", err)
-			time.Sleep(delay)
-			continue
-		}
-
-		return result.Docs, nil
+// GetPointWeather returns the daily POWER meteorology at a position for
+// every day from start to end. Days POWER has not processed yet come back
+// with nil values.
+func (c *NASAClient) GetPointWeather(ctx context.Context, latitude, longitude float64, start, end time.Time) ([]model.DailyWeather, error) {
+	params := neturl.Values{}
+	params.Set("parameters", strings.Join(model.PowerParameters, ","))
+	params.Set("community", "RE")
+	params.Set("latitude", strconv.FormatFloat(latitude, 'f', -1, 64))
+	params.Set("longitude", strconv.FormatFloat(longitude, 'f', -1, 64))
+	params.Set("start", start.UTC().Format("20060102"))
+	params.Set("end", end.UTC().Format("20060102"))
+	params.Set("format", "JSON")
+
+	url := c.config.POWERBaseURL + "/temporal/daily/point?" + params.Encode()
+	power, err := getJSON[model.NasaPower](ctx, c.power, url, CacheNASA)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch POWER weather: %w", err)
 	}
-
-	return nil, fmt.Errorf("unexpected error: max retries exceeded")
+	return power.Days(), nil
 }
 
-func (c *SpaceXClient) calculateBackoffDelay(attempt int) time.Duration {
-	delay := float64(c.config.BaseDelay) * float64(attempt+1)
-
-	jitter := delay * 0.25 * (rand.Float64()*2 - 1)
-	delay += jitter
-
-	if delay > float64(c.config.MaxDelay) {
-		delay = float64(c.config.MaxDelay)
+func (c *NASAClient) GetAsteroids(ctx context.Context, queryParams string) (model.NasaAsteroid, error) {
+	url := c.config.NASABaseURL + "/neo/rest/v1/feed" + queryParams
+	asteroids, err := getJSON[model.NasaAsteroid](ctx, c.requester, url, CacheNASA)
+	if err != nil {
+		return model.NasaAsteroid{}, fmt.Errorf("failed to fetch asteroid data: %w", err)
 	}
-
-	return time.Duration(delay)
+	return asteroids, nil
 }
 
-func (c *NASAClient) calculateBackoffDelay(attempt int) time.Duration {
-	delay := float64(c.config.BaseDelay) * float64(attempt+1)
-
-	jitter := delay * 0.25 * (rand.Float64()*2 - 1)
-	delay += jitter
+// GetAPOD returns the Astronomy Picture of the Day entries selected by
+// query, oldest first for date ranges.
+func (c *NASAClient) GetAPOD(ctx context.Context, query *APODQuery) ([]model.APOD, error) {
+	url := c.config.NASABaseURL + "/planetary/apod" + query.Encode()
+	// Random picks must not be served from the cache.
+	class := CacheNASA
+	if query != nil && query.count > 0 {
+		class = ""
+	}
+	apods, err := fetch[[]model.APOD](ctx, c.requester, Request{
+		Method:  http.MethodGet,
+		URL:     url,
+		Cache:   class,
+		Decoder: apodDecoder{},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch astronomy picture of the day: %w", err)
+	}
+	return apods, nil
+}
 
-	if delay > float64(c.config.MaxDelay) {
-		delay = float64(c.config.MaxDelay)
+// DownloadMedia writes the file at mediaURL, e.g. an APOD image, to w and
+// returns its size. Files larger than MaxMediaBytes are cut off with an
+// error.
+func (c *NASAClient) DownloadMedia(ctx context.Context, mediaURL string, w io.Writer) (int64, error) {
+	n, err := c.media.Stream(ctx, mediaURL, w, MaxMediaBytes)
+	if err != nil {
+		return n, fmt.Errorf("failed to download %s: %w", mediaURL, err)
 	}
+	return n, nil
+}
 
-	return time.Duration(delay)
+// GetAsteroid looks up a single object by its NEO reference ID, including
+// its orbital data and full close-approach history.
+func (c *NASAClient) GetAsteroid(ctx context.Context, id string) (model.NasaAsteroidObject, error) {
+	url := c.config.NASABaseURL + "/neo/rest/v1/neo/" + neturl.PathEscape(id)
+	asteroid, err := getJSON[model.NasaAsteroidObject](ctx, c.requester, url, CacheNASA)
+	if err != nil {
+		return model.NasaAsteroidObject{}, fmt.Errorf("failed to fetch asteroid %s: %w", id, err)
+	}
+	return asteroid, nil
 }
 
-func BuildWeatherEventsQueryParams(long, lat float64, date time.Time) string {
-	return fmt.Sprintf("?bbox=%f,%f,%f,%f&start=%s&end=%s", long-1, lat-1, long+1, lat+1, date.Format("2006-01-02"), date.Format("2006-01-02"))
+// BrowseAsteroids returns one page of the overall NeoWs catalogue. Pages
+// start at 0.
+func (c *NASAClient) BrowseAsteroids(ctx context.Context, page, size int) (model.NasaAsteroidBrowse, error) {
+	url := fmt.Sprintf("%s/neo/rest/v1/neo/browse?page=%d&size=%d", c.config.NASABaseURL, page, size)
+	browse, err := getJSON[model.NasaAsteroidBrowse](ctx, c.requester, url, CacheNASA)
+	if err != nil {
+		return model.NasaAsteroidBrowse{}, fmt.Errorf("failed to browse asteroids: %w", err)
+	}
+	return browse, nil
 }
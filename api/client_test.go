@@ -0,0 +1,172 @@
+package api
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientsUseConfiguredBaseURLs(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		switch r.URL.Path {
+		case "/spacex/rockets":
+			io.WriteString(w, `[{"id":"falcon9","name":"Falcon 9"}]`)
+		case "/eonet/events":
+			io.WriteString(w, `{"events":[{"id":"EONET_1","title":"Storm"}]}`)
+		case "/nasa/neo/rest/v1/feed":
+			io.WriteString(w, `{"element_count":3}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	config := testConfig()
+	config.SpaceXBaseURL = server.URL + "/spacex"
+	config.NASABaseURL = server.URL + "/nasa"
+	config.EONETBaseURL = server.URL + "/eonet"
+
+	spaceX := NewSpaceXClient(config, testLogger())
+	nasa := NewNASAClient(config, testLogger())
+
+	rockets, err := spaceX.GetAllRockets(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "Falcon 9", rockets["falcon9"].Name)
+
+	events, err := nasa.GetEarthEvents(context.Background(), NewEventQuery().Status(EventsOpen))
+	require.NoError(t, err)
+	assert.Len(t, events, 1)
+
+	asteroids, err := nasa.GetAsteroids(context.Background(), "?start_date=2024-01-01&end_date=2024-01-01")
+	require.NoError(t, err)
+	assert.Equal(t, 3, asteroids.ElementCount)
+
+	assert.Equal(t, []string{"/spacex/rockets", "/eonet/events", "/nasa/neo/rest/v1/feed"}, paths)
+}
+
+func TestNASAClientAsteroidLookupAndBrowse(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.Path+"?"+r.URL.Query().Get("page")+"/"+r.URL.Query().Get("size"))
+		assert.Equal(t, "test-key", r.URL.Query().Get("api_key"))
+		switch r.URL.Path {
+		case "/neo/rest/v1/neo/3542519":
+			io.WriteString(w, `{"id":"3542519","name":"(2010 PK9)","orbital_data":{"semi_major_axis":"1.5","eccentricity":"0.35","inclination":"12.5","orbit_class":{"orbit_class_type":"APO"}}}`)
+		case "/neo/rest/v1/neo/browse":
+			io.WriteString(w, `{"page":{"size":2,"total_elements":3,"total_pages":2,"number":1},"near_earth_objects":[{"id":"3"}]}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			io.WriteString(w, `{"code":404}`)
+		}
+	}))
+	defer server.Close()
+
+	config := testConfig()
+	config.NASABaseURL = server.URL
+	config.NASAAPIKey = "test-key"
+	nasa := NewNASAClient(config, testLogger())
+
+	asteroid, err := nasa.GetAsteroid(context.Background(), "3542519")
+	require.NoError(t, err)
+	require.NotNil(t, asteroid.OrbitalData)
+	assert.Equal(t, 1.5, asteroid.OrbitalData.SemiMajorAxis)
+	assert.Equal(t, "APO", asteroid.OrbitalData.OrbitClass.Type)
+
+	browse, err := nasa.BrowseAsteroids(context.Background(), 1, 2)
+	require.NoError(t, err)
+	assert.Equal(t, 3, browse.Page.TotalElements)
+	require.Len(t, browse.NearEarthObjects, 1)
+
+	_, err = nasa.GetAsteroid(context.Background(), "missing")
+	var statusErr *StatusError
+	require.ErrorAs(t, err, &statusErr)
+	assert.Equal(t, http.StatusNotFound, statusErr.StatusCode)
+
+	assert.Equal(t, []string{"/neo/rest/v1/neo/3542519?/", "/neo/rest/v1/neo/browse?1/2", "/neo/rest/v1/neo/missing?/"}, requests)
+}
+
+func TestNASAClientPointWeather(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/temporal/daily/point", r.URL.Path)
+		query := r.URL.Query()
+		assert.Empty(t, query.Get("api_key"), "POWER does not take the NASA key")
+		assert.Equal(t, "T2M,T2M_MAX,T2M_MIN,WS10M,WS10M_MAX,PRECTOTCORR,CLOUD_AMT", query.Get("parameters"))
+		assert.Equal(t, "28.6", query.Get("latitude"))
+		assert.Equal(t, "-80.6", query.Get("longitude"))
+		assert.Equal(t, "20240926", query.Get("start"))
+		assert.Equal(t, "20240926", query.Get("end"))
+		io.WriteString(w, `{"properties":{"parameter":{"T2M":{"20240926":27.1},"WS10M":{"20240926":6.5}}},"header":{"fill_value":-999.0}}`)
+	}))
+	defer server.Close()
+
+	config := testConfig()
+	config.POWERBaseURL = server.URL + "/api"
+	config.NASAAPIKey = "test-key"
+	nasa := NewNASAClient(config, testLogger())
+
+	day := time.Date(2024, 9, 26, 0, 0, 0, 0, time.UTC)
+	days, err := nasa.GetPointWeather(context.Background(), 28.6, -80.6, day, day)
+	require.NoError(t, err)
+	require.Len(t, days, 1)
+	assert.Equal(t, day, days[0].Date)
+	assert.Equal(t, 27.1, *days[0].Temperature)
+	assert.Equal(t, 6.5, *days[0].WindSpeed)
+	assert.Nil(t, days[0].CloudCover)
+}
+
+func TestSpaceXClientCollections(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests = append(requests, r.Method+" "+r.URL.Path+" "+string(body))
+		switch r.URL.Path {
+		case "/cores":
+			io.WriteString(w, `[{"id":"5e9e28a6f35918c0803b265c","serial":"B1049","block":5,"status":"lost","launches":["l1","l2"]}]`)
+		case "/cores/5e9e28a6f35918c0803b265c":
+			io.WriteString(w, `{"id":"5e9e28a6f35918c0803b265c","serial":"B1049","reuse_count":9,"asds_attempts":10,"asds_landings":9}`)
+		case "/payloads/query":
+			io.WriteString(w, `{"docs":[{"id":"p1","name":"Starlink-1","type":"Satellite","launch":"l1","mass_kg":15600,"orbit":"VLEO","customers":["SpaceX"],"dragon":{"capsule":null}}],"totalDocs":1,"page":1,"totalPages":1}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	config := testConfig()
+	config.SpaceXBaseURL = server.URL
+	spaceX := NewSpaceXClient(config, testLogger())
+	ctx := context.Background()
+
+	cores, err := spaceX.GetAllCores(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 5, *cores["5e9e28a6f35918c0803b265c"].Block)
+
+	core, err := spaceX.GetCore(ctx, "5e9e28a6f35918c0803b265c")
+	require.NoError(t, err)
+	assert.Equal(t, 9, core.ASDSLandings)
+
+	page, err := spaceX.QueryPayloads(ctx, NewCollectionQuery().In("launch", "l1", "l2"))
+	require.NoError(t, err)
+	require.Len(t, page.Docs, 1)
+	assert.Equal(t, 15600.0, *page.Docs[0].MassKg)
+
+	_, err = spaceX.GetShip(ctx, "missing")
+	var statusErr *StatusError
+	require.ErrorAs(t, err, &statusErr)
+	assert.Equal(t, http.StatusNotFound, statusErr.StatusCode)
+
+	assert.Equal(t, []string{
+		"GET /cores ",
+		"GET /cores/5e9e28a6f35918c0803b265c ",
+		`POST /payloads/query {"query":{"launch":{"$in":["l1","l2"]}},"options":{}}`,
+		"GET /ships/missing ",
+	}, requests)
+}
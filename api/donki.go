@@ -0,0 +1,95 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/MitiaRD/ReMarkable-cli/model"
+)
+
+// DONKIAPI is the subset of NASA's Space Weather Database Of Notifications,
+// Knowledge, Information (DONKI) used by the CLI. Every method returns the
+// events that started between start and end, inclusive of whole days.
+type DONKIAPI interface {
+	GetSolarFlares(ctx context.Context, start, end time.Time) ([]model.SolarFlare, error)
+	GetCMEs(ctx context.Context, start, end time.Time) ([]model.CME, error)
+	GetGeomagneticStorms(ctx context.Context, start, end time.Time) ([]model.GeomagneticStorm, error)
+	GetSEPs(ctx context.Context, start, end time.Time) ([]model.SolarEnergeticParticle, error)
+}
+
+var _ DONKIAPI = (*DONKIClient)(nil)
+
+// DONKIClient talks to the DONKI endpoints on api.nasa.gov. It sends its
+// requests through a NASAClient's requester, so they count against the same
+// key's rate limit and show up in NASAClient.Quota.
+type DONKIClient struct {
+	requester *Requester
+	logger    *slog.Logger
+	config    *model.Config
+}
+
+func NewDONKIClient(nasa *NASAClient) *DONKIClient {
+	return &DONKIClient{
+		requester: nasa.requester,
+		logger:    nasa.logger,
+		config:    nasa.config,
+	}
+}
+
+func (c *DONKIClient) GetSolarFlares(ctx context.Context, start, end time.Time) ([]model.SolarFlare, error) {
+	flares, err := getDONKI[model.SolarFlare](ctx, c, "FLR", start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch solar flares: %w", err)
+	}
+	return flares, nil
+}
+
+func (c *DONKIClient) GetCMEs(ctx context.Context, start, end time.Time) ([]model.CME, error) {
+	cmes, err := getDONKI[model.CME](ctx, c, "CME", start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch coronal mass ejections: %w", err)
+	}
+	return cmes, nil
+}
+
+func (c *DONKIClient) GetGeomagneticStorms(ctx context.Context, start, end time.Time) ([]model.GeomagneticStorm, error) {
+	storms, err := getDONKI[model.GeomagneticStorm](ctx, c, "GST", start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch geomagnetic storms: %w", err)
+	}
+	return storms, nil
+}
+
+func (c *DONKIClient) GetSEPs(ctx context.Context, start, end time.Time) ([]model.SolarEnergeticParticle, error) {
+	seps, err := getDONKI[model.SolarEnergeticParticle](ctx, c, "SEP", start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch solar energetic particles: %w", err)
+	}
+	return seps, nil
+}
+
+func getDONKI[T any](ctx context.Context, c *DONKIClient, endpoint string, start, end time.Time) ([]T, error) {
+	url := fmt.Sprintf("%s/DONKI/%s?startDate=%s&endDate=%s", c.config.NASABaseURL, endpoint,
+		start.UTC().Format(time.DateOnly), end.UTC().Format(time.DateOnly))
+	return fetch[[]T](ctx, c.requester, Request{
+		Method:  http.MethodGet,
+		URL:     url,
+		Cache:   CacheNASA,
+		Decoder: donkiDecoder{},
+	})
+}
+
+// donkiDecoder accepts the empty body DONKI sends instead of [] when nothing
+// happened in the requested period.
+type donkiDecoder struct{}
+
+func (donkiDecoder) Decode(body []byte, v any) error {
+	if len(bytes.TrimSpace(body)) == 0 {
+		body = []byte("[]")
+	}
+	return JSONDecoder{}.Decode(body, v)
+}
@@ -0,0 +1,79 @@
+package api
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDONKIClient(t *testing.T) {
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "test-key", r.URL.Query().Get("api_key"))
+		queries = append(queries, r.URL.Path+"?"+r.URL.Query().Get("startDate")+"/"+r.URL.Query().Get("endDate"))
+		w.Header().Set("X-RateLimit-Limit", "1000")
+		w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(1000-len(queries)))
+		switch r.URL.Path {
+		case "/DONKI/FLR":
+			io.WriteString(w, `[{"flrID":"2024-05-14T16:46:00-FLR-001","beginTime":"2024-05-14T16:46Z","peakTime":"2024-05-14T16:51Z","endTime":null,"classType":"X8.7","sourceLocation":"S18W89","activeRegionNum":13664}]`)
+		case "/DONKI/CME":
+			io.WriteString(w, `[{"activityID":"2024-05-14T17:12:00-CME-001","startTime":"2024-05-14T17:12Z","cmeAnalyses":[{"speed":1200,"halfAngle":40,"type":"O","isMostAccurate":true}]}]`)
+		case "/DONKI/GST":
+			io.WriteString(w, `[{"gstID":"2024-05-10T15:00:00-GST-001","startTime":"2024-05-10T15:00Z","allKpIndex":[{"observedTime":"2024-05-10T18:00Z","kpIndex":8.33,"source":"NOAA"},{"observedTime":"2024-05-11T00:00Z","kpIndex":9,"source":"NOAA"}]}]`)
+		case "/DONKI/SEP":
+			// DONKI answers with an empty body when nothing happened.
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	config := testConfig()
+	config.NASABaseURL = server.URL
+	config.NASAAPIKey = "test-key"
+	nasa := NewNASAClient(config, testLogger())
+	donki := NewDONKIClient(nasa)
+
+	start := time.Date(2024, 5, 10, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 5, 15, 0, 0, 0, 0, time.UTC)
+	ctx := context.Background()
+
+	flares, err := donki.GetSolarFlares(ctx, start, end)
+	require.NoError(t, err)
+	require.Len(t, flares, 1)
+	assert.Equal(t, "X8.7", flares[0].ClassType)
+	assert.Equal(t, time.Date(2024, 5, 14, 16, 51, 0, 0, time.UTC), flares[0].PeakTime.Time)
+	assert.True(t, flares[0].EndTime.IsZero())
+	assert.Equal(t, 13664, *flares[0].ActiveRegionNum)
+
+	cmes, err := donki.GetCMEs(ctx, start, end)
+	require.NoError(t, err)
+	require.Len(t, cmes, 1)
+	speed, ok := cmes[0].Speed()
+	assert.True(t, ok)
+	assert.Equal(t, 1200.0, speed)
+
+	storms, err := donki.GetGeomagneticStorms(ctx, start, end)
+	require.NoError(t, err)
+	require.Len(t, storms, 1)
+	assert.Equal(t, 9.0, storms[0].MaxKp())
+
+	seps, err := donki.GetSEPs(ctx, start, end)
+	require.NoError(t, err)
+	assert.Empty(t, seps)
+
+	assert.Equal(t, []string{
+		"/DONKI/FLR?2024-05-10/2024-05-15",
+		"/DONKI/CME?2024-05-10/2024-05-15",
+		"/DONKI/GST?2024-05-10/2024-05-15",
+		"/DONKI/SEP?2024-05-10/2024-05-15",
+	}, queries)
+	assert.Equal(t, RateLimitStatus{Known: true, Limit: 1000, Remaining: 996}, nasa.Quota(), "DONKI shares the NASA key's quota")
+}
@@ -0,0 +1,200 @@
+package api
+
+import (
+	"math"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/MitiaRD/ReMarkable-cli/model"
+)
+
+type EventStatus string
+
+const (
+	EventsOpen   EventStatus = "open"
+	EventsClosed EventStatus = "closed"
+	EventsAll    EventStatus = "all"
+)
+
+const eonetDateLayout = "2006-01-02"
+
+// EventQuery builds the query string for the EONET /events endpoint. The zero
+// value asks for every open event, which is the server's default.
+type EventQuery struct {
+	categories   []string
+	sources      []string
+	status       EventStatus
+	days         int
+	limit        int
+	start        *time.Time
+	end          *time.Time
+	magnitudeID  string
+	magnitudeMin *float64
+	magnitudeMax *float64
+	bbox         []float64
+}
+
+func NewEventQuery() *EventQuery {
+	return &EventQuery{}
+}
+
+// Category restricts events to the given category IDs, e.g. "severeStorms".
+func (q *EventQuery) Category(ids ...string) *EventQuery {
+	q.categories = append(q.categories, ids...)
+	return q
+}
+
+// Source restricts events to those reported by the given source IDs.
+func (q *EventQuery) Source(ids ...string) *EventQuery {
+	q.sources = append(q.sources, ids...)
+	return q
+}
+
+func (q *EventQuery) Status(status EventStatus) *EventQuery {
+	q.status = status
+	return q
+}
+
+// Days restricts events to those seen within the last n days.
+func (q *EventQuery) Days(n int) *EventQuery {
+	q.days = n
+	return q
+}
+
+func (q *EventQuery) Limit(n int) *EventQuery {
+	q.limit = n
+	return q
+}
+
+// Between restricts events to those observed between the start and end
+// dates, inclusive.
+func (q *EventQuery) Between(start, end time.Time) *EventQuery {
+	q.start = &start
+	q.end = &end
+	return q
+}
+
+// Magnitude restricts events to those whose magnitude, measured as id (e.g.
+// "mag_kts"), lies within min and max.
+func (q *EventQuery) Magnitude(id string, min, max float64) *EventQuery {
+	q.magnitudeID = id
+	q.magnitudeMin = &min
+	q.magnitudeMax = &max
+	return q
+}
+
+// MagnitudeMin restricts events to those with a magnitude of at least min.
+func (q *EventQuery) MagnitudeMin(id string, min float64) *EventQuery {
+	q.magnitudeID = id
+	q.magnitudeMin = &min
+	return q
+}
+
+// MagnitudeMax restricts events to those with a magnitude of at most max.
+func (q *EventQuery) MagnitudeMax(id string, max float64) *EventQuery {
+	q.magnitudeID = id
+	q.magnitudeMax = &max
+	return q
+}
+
+// BBox restricts events to those with a position inside the box.
+func (q *EventQuery) BBox(minLon, minLat, maxLon, maxLat float64) *EventQuery {
+	q.bbox = []float64{minLon, minLat, maxLon, maxLat}
+	return q
+}
+
+// Encode returns the query string, including the leading "?", or "" for an
+// empty or nil query.
+func (q *EventQuery) Encode() string {
+	if q == nil {
+		return ""
+	}
+	values := url.Values{}
+	if len(q.categories) > 0 {
+		values.Set("category", strings.Join(q.categories, ","))
+	}
+	if len(q.sources) > 0 {
+		values.Set("source", strings.Join(q.sources, ","))
+	}
+	if q.status != "" {
+		values.Set("status", string(q.status))
+	}
+	if q.days > 0 {
+		values.Set("days", strconv.Itoa(q.days))
+	}
+	if q.limit > 0 {
+		values.Set("limit", strconv.Itoa(q.limit))
+	}
+	if q.start != nil {
+		values.Set("start", q.start.Format(eonetDateLayout))
+	}
+	if q.end != nil {
+		values.Set("end", q.end.Format(eonetDateLayout))
+	}
+	if q.magnitudeID != "" {
+		values.Set("magID", q.magnitudeID)
+	}
+	if q.magnitudeMin != nil {
+		values.Set("magMin", formatFloat(*q.magnitudeMin))
+	}
+	if q.magnitudeMax != nil {
+		values.Set("magMax", formatFloat(*q.magnitudeMax))
+	}
+	if q.bbox != nil {
+		// EONET expects the upper-left corner followed by the lower-right one.
+		corners := []float64{q.bbox[0], q.bbox[3], q.bbox[2], q.bbox[1]}
+		parts := make([]string, len(corners))
+		for i, corner := range corners {
+			parts[i] = formatFloat(corner)
+		}
+		values.Set("bbox", strings.Join(parts, ","))
+	}
+
+	if len(values) == 0 {
+		return ""
+	}
+	// Commas are left unescaped to match the EONET documentation.
+	return "?" + strings.ReplaceAll(values.Encode(), "%2C", ",")
+}
+
+// kmPerDegree is the length of one degree of latitude.
+const kmPerDegree = math.Pi * model.EarthRadiusKm / 180
+
+// HazardQuery asks for events of any status observed within radiusKm of the
+// given position and within windowDays either side of date. The bounding box
+// sent to EONET only approximates the circle; callers should post-filter the
+// results by great-circle distance.
+func HazardQuery(lon, lat float64, date time.Time, radiusKm float64, windowDays int) *EventQuery {
+	minLon, minLat, maxLon, maxLat := RadiusBBox(lon, lat, radiusKm)
+	return NewEventQuery().
+		Status(EventsAll).
+		BBox(minLon, minLat, maxLon, maxLat).
+		Between(date.AddDate(0, 0, -windowDays), date.AddDate(0, 0, windowDays))
+}
+
+// RadiusBBox returns a box containing every position within radiusKm of the
+// given one. Near the poles, or where the circle crosses the antimeridian,
+// the box spans every longitude.
+func RadiusBBox(lon, lat, radiusKm float64) (minLon, minLat, maxLon, maxLat float64) {
+	dLat := radiusKm / kmPerDegree
+	minLat = math.Max(-90, lat-dLat)
+	maxLat = math.Min(90, lat+dLat)
+	if minLat == -90 || maxLat == 90 {
+		return -180, minLat, 180, maxLat
+	}
+
+	// Widen the box by the longitude span at the latitude furthest from the
+	// equator, where degrees of longitude are shortest.
+	cos := math.Cos(math.Max(math.Abs(minLat), math.Abs(maxLat)) * math.Pi / 180)
+	dLon := radiusKm / (kmPerDegree * cos)
+	if lon-dLon < -180 || lon+dLon > 180 {
+		return -180, minLat, 180, maxLat
+	}
+	return lon - dLon, minLat, lon + dLon, maxLat
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
@@ -0,0 +1,88 @@
+package api
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventQueryEncode(t *testing.T) {
+	day := time.Date(2024, 9, 26, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		query    *EventQuery
+		expected string
+	}{
+		{
+			name:     "empty query",
+			query:    NewEventQuery(),
+			expected: "",
+		},
+		{
+			name:     "categories, sources and status",
+			query:    NewEventQuery().Category("severeStorms", "wildfires").Source("JTWC").Status(EventsClosed),
+			expected: "?category=severeStorms,wildfires&source=JTWC&status=closed",
+		},
+		{
+			name:     "days and limit",
+			query:    NewEventQuery().Days(20).Limit(5),
+			expected: "?days=20&limit=5",
+		},
+		{
+			name:     "magnitude range",
+			query:    NewEventQuery().Magnitude("mag_kts", 50, 120.5),
+			expected: "?magID=mag_kts&magMax=120.5&magMin=50",
+		},
+		{
+			name:     "bbox is sent as upper-left then lower-right",
+			query:    NewEventQuery().BBox(-81.5, 27.5, -79.5, 29.5).Between(day, day),
+			expected: "?bbox=-81.5,29.5,-79.5,27.5&end=2024-09-26&start=2024-09-26",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.query.Encode())
+		})
+	}
+}
+
+func TestRadiusBBox(t *testing.T) {
+	tests := []struct {
+		name                           string
+		lon, lat, radius               float64
+		minLon, minLat, maxLon, maxLat float64
+	}{
+		{name: "equator", lon: 0, lat: 0, radius: 111.19, minLon: -1, minLat: -1, maxLon: 1, maxLat: 1},
+		{name: "high latitude widens longitude", lon: 20, lat: 60, radius: 111.19, minLon: 17.94, minLat: 59, maxLon: 22.06, maxLat: 61},
+		{name: "near the pole spans every longitude", lon: 0, lat: 89.5, radius: 111.19, minLon: -180, minLat: 88.5, maxLon: 180, maxLat: 90},
+		{name: "across the antimeridian spans every longitude", lon: 179.8, lat: 0, radius: 111.19, minLon: -180, minLat: -1, maxLon: 180, maxLat: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			minLon, minLat, maxLon, maxLat := RadiusBBox(tt.lon, tt.lat, tt.radius)
+			assert.InDelta(t, tt.minLon, minLon, 0.01)
+			assert.InDelta(t, tt.minLat, minLat, 0.01)
+			assert.InDelta(t, tt.maxLon, maxLon, 0.01)
+			assert.InDelta(t, tt.maxLat, maxLat, 0.01)
+		})
+	}
+}
+
+func TestHazardQuery(t *testing.T) {
+	day := time.Date(2024, 9, 26, 15, 0, 0, 0, time.UTC)
+	values, err := url.ParseQuery(strings.TrimPrefix(HazardQuery(-80.6, 28.6, day, kmPerDegree, 2).Encode(), "?"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "all", values.Get("status"))
+	assert.Equal(t, "2024-09-24", values.Get("start"))
+	assert.Equal(t, "2024-09-28", values.Get("end"))
+	minLon, minLat, maxLon, maxLat := RadiusBBox(-80.6, 28.6, kmPerDegree)
+	assert.Equal(t, strings.Join([]string{formatFloat(minLon), formatFloat(maxLat), formatFloat(maxLon), formatFloat(minLat)}, ","), values.Get("bbox"))
+}
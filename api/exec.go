@@ -0,0 +1,243 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/MitiaRD/ReMarkable-cli/model"
+)
+
+// ExecProtocolVersion is the version of the JSON-over-stdio protocol sent
+// with every request to an external provider.
+const ExecProtocolVersion = 1
+
+const execProviderType = "exec"
+
+// Methods of the JSON-over-stdio protocol.
+const (
+	ExecMethodLaunches   = "launches"
+	ExecMethodRockets    = "rockets"
+	ExecMethodLaunchpads = "launchpads"
+)
+
+func init() {
+	RegisterProvider(execProviderType, func(options ProviderOptions) (LaunchProvider, error) {
+		return NewExecProvider(options)
+	})
+}
+
+// ExecRequest is written to an external provider's stdin.
+type ExecRequest struct {
+	Version  int    `json:"version"`
+	Method   string `json:"method"`
+	Provider string `json:"provider"`
+	// Query is only sent with launches requests.
+	Query    *ExecQuery     `json:"query,omitempty"`
+	Settings map[string]any `json:"settings,omitempty"`
+}
+
+// ExecQuery holds the filters of a launch query. Providers may use them to
+// return fewer launches; the CLI applies them again either way.
+type ExecQuery struct {
+	DateFrom *time.Time `json:"date_from,omitempty"`
+	DateTo   *time.Time `json:"date_to,omitempty"`
+	Success  *bool      `json:"success,omitempty"`
+	Upcoming *bool      `json:"upcoming,omitempty"`
+}
+
+// ExecResponse is read from an external provider's stdout. Only the field
+// for the requested method is used, unless Error is set.
+type ExecResponse struct {
+	Launches   []model.Launch    `json:"launches,omitempty"`
+	Rockets    []model.Rocket    `json:"rockets,omitempty"`
+	Launchpads []model.Launchpad `json:"launchpads,omitempty"`
+	Error      string            `json:"error,omitempty"`
+}
+
+var _ LaunchProvider = (*ExecProvider)(nil)
+
+// ExecProvider serves launches from an external executable, so providers can
+// live outside this repository and be written in any language.
+//
+// Every call runs the command once: it gets one ExecRequest as JSON on
+// stdin and must print one ExecResponse as JSON on stdout before exiting.
+// Launches use the SpaceX launch schema; the rocket and launchpad may be
+// embedded objects rather than IDs. A provider reports failure by setting
+// the response's error or by exiting non-zero, in which case its stderr
+// becomes the error message.
+//
+// The CLI filters, sorts and pages the returned launches itself, so a
+// provider may ignore the query and return everything it has.
+type ExecProvider struct {
+	name     string
+	command  string
+	args     []string
+	timeout  time.Duration
+	settings map[string]any
+	logger   *slog.Logger
+
+	mu sync.Mutex
+	// launches remembers responses by query filter, so paging through a
+	// query runs the command once.
+	launches map[string][]model.Launch
+}
+
+// NewExecProvider builds the provider that runs options.Settings.Command.
+func NewExecProvider(options ProviderOptions) (*ExecProvider, error) {
+	if options.Settings.Command == "" {
+		return nil, fmt.Errorf("no command configured")
+	}
+	command, err := exec.LookPath(options.Settings.Command)
+	if err != nil {
+		return nil, err
+	}
+
+	timeout := options.Settings.Timeout
+	if timeout == 0 && options.Config != nil {
+		timeout = options.Config.Timeout
+	}
+	logger := options.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &ExecProvider{
+		name:     options.Name,
+		command:  command,
+		args:     options.Settings.Args,
+		timeout:  timeout,
+		settings: options.Settings.Settings,
+		logger:   logger,
+		launches: make(map[string][]model.Launch),
+	}, nil
+}
+
+func (p *ExecProvider) Name() string {
+	return p.name
+}
+
+func (p *ExecProvider) QueryLaunches(ctx context.Context, query *LaunchQuery) (Page[model.Launch], error) {
+	if query == nil {
+		query = NewLaunchQuery()
+	}
+	launches, err := p.getLaunches(ctx, execQuery(query))
+	if err != nil {
+		return Page[model.Launch]{}, err
+	}
+
+	matches := []model.Launch{}
+	for _, launch := range launches {
+		if query.Matches(launch) {
+			matches = append(matches, launch)
+		}
+	}
+	SortLaunches(matches, query.Options().Sort)
+	return Paginate(matches, query.Options()), nil
+}
+
+func (p *ExecProvider) GetRockets(ctx context.Context) (map[string]model.Rocket, error) {
+	response, err := p.call(ctx, ExecRequest{Method: ExecMethodRockets})
+	if err != nil {
+		return nil, err
+	}
+	rockets := make(map[string]model.Rocket, len(response.Rockets))
+	for _, rocket := range response.Rockets {
+		rockets[rocket.ID] = rocket
+	}
+	return rockets, nil
+}
+
+func (p *ExecProvider) GetLaunchpads(ctx context.Context) (map[string]model.Launchpad, error) {
+	response, err := p.call(ctx, ExecRequest{Method: ExecMethodLaunchpads})
+	if err != nil {
+		return nil, err
+	}
+	launchpads := make(map[string]model.Launchpad, len(response.Launchpads))
+	for _, launchpad := range response.Launchpads {
+		launchpads[launchpad.ID] = launchpad
+	}
+	return launchpads, nil
+}
+
+func (p *ExecProvider) getLaunches(ctx context.Context, query ExecQuery) ([]model.Launch, error) {
+	key, err := json.Marshal(query)
+	if err != nil {
+		return nil, err
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if launches, ok := p.launches[string(key)]; ok {
+		return launches, nil
+	}
+	response, err := p.call(ctx, ExecRequest{Method: ExecMethodLaunches, Query: &query})
+	if err != nil {
+		return nil, err
+	}
+	p.launches[string(key)] = response.Launches
+	return response.Launches, nil
+}
+
+// call runs the command for one request and decodes its response.
+func (p *ExecProvider) call(ctx context.Context, request ExecRequest) (ExecResponse, error) {
+	request.Version = ExecProtocolVersion
+	request.Provider = p.name
+	request.Settings = p.settings
+	input, err := json.Marshal(request)
+	if err != nil {
+		return ExecResponse{}, err
+	}
+
+	parent := ctx
+	if p.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, p.timeout)
+		defer cancel()
+	}
+	cmd := exec.CommandContext(ctx, p.command, p.args...)
+	cmd.Stdin = bytes.NewReader(append(input, '\n'))
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	start := time.Now()
+	runErr := cmd.Run()
+	p.logger.Debug("external provider call", "provider", p.name, "method", request.Method, "duration", time.Since(start))
+
+	var response ExecResponse
+	decodeErr := json.Unmarshal(stdout.Bytes(), &response)
+	switch {
+	case decodeErr == nil && response.Error != "":
+		return ExecResponse{}, fmt.Errorf("%s %s: %s", p.name, request.Method, response.Error)
+	case ctx.Err() != nil && parent.Err() == nil:
+		// Only the provider's own timeout can end ctx before its parent.
+		return ExecResponse{}, fmt.Errorf("%s %s timed out after %s", p.name, request.Method, p.timeout)
+	case ctx.Err() != nil:
+		return ExecResponse{}, fmt.Errorf("%s %s: %w", p.name, request.Method, ctx.Err())
+	case runErr != nil:
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return ExecResponse{}, fmt.Errorf("%s %s failed: %w: %s", p.name, request.Method, runErr, message)
+		}
+		return ExecResponse{}, fmt.Errorf("%s %s failed: %w", p.name, request.Method, runErr)
+	case decodeErr != nil:
+		return ExecResponse{}, fmt.Errorf("%s %s returned invalid JSON: %w", p.name, request.Method, decodeErr)
+	}
+	return response, nil
+}
+
+// execQuery picks the filters of query that mean something to any provider.
+// Rocket, launchpad and crew IDs are left out as they are SpaceX IDs.
+func execQuery(query *LaunchQuery) ExecQuery {
+	return ExecQuery{
+		DateFrom: query.dateFrom,
+		DateTo:   query.dateTo,
+		Success:  query.success,
+		Upcoming: query.upcoming,
+	}
+}
@@ -0,0 +1,164 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/MitiaRD/ReMarkable-cli/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestExecProviderHelper is the external provider run by the exec provider
+// tests. It appends every request to the file named by the "log" setting and
+// misbehaves as the "mode" setting asks.
+func TestExecProviderHelper(t *testing.T) {
+	if os.Getenv("SPACE_CLI_EXEC_HELPER") != "1" {
+		t.Skip("only run as an external provider")
+	}
+
+	var request ExecRequest
+	if err := json.NewDecoder(os.Stdin).Decode(&request); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	if log, ok := request.Settings["log"].(string); ok {
+		line, _ := json.Marshal(request)
+		f, _ := os.OpenFile(log, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+		fmt.Fprintln(f, string(line))
+		f.Close()
+	}
+
+	switch request.Settings["mode"] {
+	case "exit":
+		fmt.Fprintln(os.Stderr, "manifest database offline")
+		os.Exit(3)
+	case "error":
+		fmt.Println(`{"error": "not authorised"}`)
+		os.Exit(0)
+	case "garbage":
+		fmt.Println("launches: none")
+		os.Exit(0)
+	case "slow":
+		time.Sleep(10 * time.Second)
+	}
+
+	launchDate := time.Date(2026, 11, 1, 9, 30, 0, 0, time.UTC)
+	var response ExecResponse
+	switch request.Method {
+	case ExecMethodLaunches:
+		response.Launches = []model.Launch{
+			{ID: "m-1", Name: "Pathfinder", Date: launchDate.AddDate(0, 0, -60), RocketId: "r-1", LaunchpadId: "p-1", Agency: "Internal"},
+			{ID: "m-3", Name: "Second Light", Date: launchDate, RocketId: "r-1", LaunchpadId: "p-1", Upcoming: true, Agency: "Internal"},
+			{ID: "m-2", Name: "First Light", Date: launchDate.AddDate(0, 0, -30), RocketId: "r-1", LaunchpadId: "p-1", Agency: "Internal"},
+		}
+	case ExecMethodRockets:
+		response.Rockets = []model.Rocket{{ID: "r-1", Name: "Sounding Rocket"}}
+	case ExecMethodLaunchpads:
+		response.Launchpads = []model.Launchpad{{ID: "p-1", Name: "Test Range Pad 1"}}
+	}
+	json.NewEncoder(os.Stdout).Encode(response)
+	os.Exit(0)
+}
+
+func newHelperProvider(t *testing.T, settings map[string]any, timeout time.Duration) *ExecProvider {
+	t.Helper()
+	t.Setenv("SPACE_CLI_EXEC_HELPER", "1")
+
+	provider, err := NewProvider(ProviderOptions{
+		Name: "manifest",
+		Settings: model.ProviderConfig{
+			Command:  os.Args[0],
+			Args:     []string{"-test.run=^TestExecProviderHelper$"},
+			Timeout:  timeout,
+			Settings: settings,
+		},
+		Config: model.DefaultConfig(),
+	})
+	require.NoError(t, err)
+	require.IsType(t, &ExecProvider{}, provider)
+	return provider.(*ExecProvider)
+}
+
+func TestExecProvider(t *testing.T) {
+	log := t.TempDir() + "/requests.jsonl"
+	provider := newHelperProvider(t, map[string]any{"log": log}, 0)
+	ctx := context.Background()
+	assert.Equal(t, "manifest", provider.Name())
+
+	query := NewLaunchQuery().Upcoming(false).SortBy("date_utc", Ascending).Limit(1)
+	var names []string
+	for launch, err := range IterateLaunches(ctx, provider, query) {
+		require.NoError(t, err)
+		names = append(names, launch.Name)
+	}
+	assert.Equal(t, []string{"Pathfinder", "First Light"}, names)
+
+	rockets, err := provider.GetRockets(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "Sounding Rocket", rockets["r-1"].Name)
+
+	launchpads, err := provider.GetLaunchpads(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "Test Range Pad 1", launchpads["p-1"].Name)
+
+	content, err := os.ReadFile(log)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	require.Len(t, lines, 3, "paging through launches runs the command once")
+
+	var request ExecRequest
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &request))
+	assert.Equal(t, ExecProtocolVersion, request.Version)
+	assert.Equal(t, ExecMethodLaunches, request.Method)
+	assert.Equal(t, "manifest", request.Provider)
+	require.NotNil(t, request.Query)
+	require.NotNil(t, request.Query.Upcoming)
+	assert.False(t, *request.Query.Upcoming)
+}
+
+func TestExecProviderErrors(t *testing.T) {
+	tests := []struct {
+		mode    string
+		timeout time.Duration
+		want    string
+	}{
+		{mode: "exit", want: "manifest database offline"},
+		{mode: "error", want: "manifest rockets: not authorised"},
+		{mode: "garbage", want: "returned invalid JSON"},
+		{mode: "slow", timeout: 100 * time.Millisecond, want: "timed out after 100ms"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			provider := newHelperProvider(t, map[string]any{"mode": tt.mode}, tt.timeout)
+			_, err := provider.GetRockets(context.Background())
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
+
+func TestExecProviderHonoursCallerDeadline(t *testing.T) {
+	provider := newHelperProvider(t, map[string]any{"mode": "slow"}, 0)
+	provider.timeout = 0
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	_, err := provider.GetRockets(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.NotContains(t, err.Error(), "timed out after")
+}
+
+func TestNewExecProviderRequiresCommand(t *testing.T) {
+	_, err := NewExecProvider(ProviderOptions{Name: "manifest"})
+	assert.Error(t, err)
+
+	_, err = NewExecProvider(ProviderOptions{Name: "manifest", Settings: model.ProviderConfig{Command: "space-cli-no-such-provider"}})
+	assert.Error(t, err)
+}
@@ -0,0 +1,295 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"log/slog"
+	"math"
+	"net/url"
+	"strconv"
+
+	"github.com/MitiaRD/ReMarkable-cli/model"
+)
+
+// Launch Library 2 serves pages of 10 documents unless asked for up to 100.
+const (
+	ll2DefaultLimit = 10
+	ll2MaxLimit     = 100
+)
+
+// LaunchLibraryAPI is the subset of The Space Devs' Launch Library 2 API used
+// by the CLI. Launches come with their rocket and pad embedded.
+type LaunchLibraryAPI interface {
+	LaunchProvider
+	QueryAgencies(ctx context.Context, query *LibraryQuery) (Page[model.Agency], error)
+}
+
+var _ LaunchLibraryAPI = (*LaunchLibraryClient)(nil)
+
+// LaunchLibraryClient talks to Launch Library 2, which covers launches by
+// every agency. Anonymous clients are limited to 15 requests an hour, so
+// responses are cached like the SpaceX ones and, at the default rate, a
+// full hour's quota may be spent at once before requests are spread out.
+type LaunchLibraryClient struct {
+	requester *Requester
+	logger    *slog.Logger
+	config    *model.Config
+}
+
+func NewLaunchLibraryClient(config *model.Config, logger *slog.Logger, opts ...RequesterOption) *LaunchLibraryClient {
+	limiter := NewRateLimiter(config.LL2RateLimit, max(int(math.Ceil(config.LL2RateLimit)), model.LL2Burst))
+	return &LaunchLibraryClient{
+		requester: NewRequester(config, logger, append([]RequesterOption{
+			WithRateLimiter(limiter),
+			WithAuth(TokenAuth{Token: config.LL2APIToken}),
+		}, opts...)...),
+		logger: logger,
+		config: config,
+	}
+}
+
+func (c *LaunchLibraryClient) Name() string {
+	return model.ProviderLaunchLibrary
+}
+
+// QueryLaunches translates the date, success, upcoming, sort and paging parts
+// of query into Launch Library 2 filters. Filters on SpaceX rocket,
+// launchpad, crew or flight number IDs are not sent. Pages hold at most 100
+// launches.
+func (c *LaunchLibraryClient) QueryLaunches(ctx context.Context, query *LaunchQuery) (Page[model.Launch], error) {
+	if query == nil {
+		query = NewLaunchQuery()
+	}
+	path, params := libraryLaunchParams(query)
+	options := libraryOptions(query.Options())
+	raw, err := getJSON[model.LL2Page[model.LL2Launch]](ctx, c.requester, c.url(path, params, options), CacheLaunches)
+	if err != nil {
+		return Page[model.Launch]{}, fmt.Errorf("failed to query Launch Library launches: %w", err)
+	}
+	return libraryPage(raw, options, model.LL2Launch.Launch), nil
+}
+
+func (c *LaunchLibraryClient) QueryAgencies(ctx context.Context, query *LibraryQuery) (Page[model.Agency], error) {
+	page, err := queryLibrary(ctx, c, "/agencies/", query, model.LL2Agency.Agency)
+	if err != nil {
+		return page, fmt.Errorf("failed to query agencies: %w", err)
+	}
+	return page, nil
+}
+
+// GetRockets walks every launcher configuration, a page of 100 per request.
+// Launches embed their rocket, so only listing rockets should need this.
+func (c *LaunchLibraryClient) GetRockets(ctx context.Context) (map[string]model.Rocket, error) {
+	return getAllLibrary(ctx, c, "/config/launcher/", "rockets", model.LL2LauncherConfig.Rocket, func(r model.Rocket) string { return r.ID })
+}
+
+// GetLaunchpads walks every pad. Like GetRockets it costs many requests and
+// is not needed to show launches.
+func (c *LaunchLibraryClient) GetLaunchpads(ctx context.Context) (map[string]model.Launchpad, error) {
+	return getAllLibrary(ctx, c, "/pad/", "launchpads", model.LL2Pad.Launchpad, func(p model.Launchpad) string { return p.ID })
+}
+
+func (c *LaunchLibraryClient) url(path string, params url.Values, options QueryOptions) string {
+	params.Set("limit", strconv.Itoa(options.Limit))
+	if options.Offset > 0 {
+		params.Set("offset", strconv.Itoa(options.Offset))
+	}
+	return c.config.LL2BaseURL + path + "?" + params.Encode()
+}
+
+func queryLibrary[R, T any](ctx context.Context, c *LaunchLibraryClient, path string, query *LibraryQuery, convert func(R) T) (Page[T], error) {
+	if query == nil {
+		query = NewLibraryQuery()
+	}
+	options := libraryOptions(query.Options())
+	raw, err := getJSON[model.LL2Page[R]](ctx, c.requester, c.url(path, query.params(), options), CacheReference)
+	if err != nil {
+		return Page[T]{}, err
+	}
+	return libraryPage(raw, options, convert), nil
+}
+
+func getAllLibrary[R, T any](ctx context.Context, c *LaunchLibraryClient, path, name string, convert func(R) T, id func(T) string) (map[string]T, error) {
+	docs := make(map[string]T)
+	query := NewLibraryQuery().Limit(ll2MaxLimit)
+	for doc, err := range IterateLibrary(ctx, query, func(ctx context.Context, query *LibraryQuery) (Page[T], error) {
+		return queryLibrary(ctx, c, path, query, convert)
+	}) {
+		if err != nil {
+			return docs, fmt.Errorf("failed to fetch %s: %w", name, err)
+		}
+		docs[id(doc)] = doc
+	}
+	return docs, nil
+}
+
+// libraryLaunchParams picks the launch list endpoint and filters for query.
+// Upcoming and previous launches have their own endpoints.
+func libraryLaunchParams(query *LaunchQuery) (string, url.Values) {
+	params := url.Values{}
+	path := "/launch/"
+	if query.upcoming != nil {
+		if *query.upcoming {
+			path = "/launch/upcoming/"
+		} else {
+			path = "/launch/previous/"
+		}
+	}
+	if query.dateFrom != nil {
+		params.Set("net__gte", query.dateFrom.UTC().Format(queryTimeLayout))
+	}
+	if query.dateTo != nil {
+		params.Set("net__lte", query.dateTo.UTC().Format(queryTimeLayout))
+	}
+	if query.success != nil {
+		if *query.success {
+			params.Set("status__ids", strconv.Itoa(model.LL2StatusSuccess))
+		} else {
+			params.Set("status__ids", fmt.Sprintf("%d,%d", model.LL2StatusFailure, model.LL2StatusPartialFailure))
+		}
+	}
+	for _, field := range query.options.Sort {
+		if field.Field != "date_utc" {
+			continue
+		}
+		if field.Order == Descending {
+			params.Set("ordering", "-net")
+		} else {
+			params.Set("ordering", "net")
+		}
+		break
+	}
+	return path, params
+}
+
+// libraryOptions turns page based options into the limit and offset that
+// Launch Library 2 understands.
+func libraryOptions(options QueryOptions) QueryOptions {
+	switch {
+	case options.Limit <= 0:
+		options.Limit = ll2DefaultLimit
+	case options.Limit > ll2MaxLimit:
+		options.Limit = ll2MaxLimit
+	}
+	if options.Page > 1 && options.Offset == 0 {
+		options.Offset = (options.Page - 1) * options.Limit
+	}
+	return options
+}
+
+// libraryPage converts a Launch Library 2 page into the mongoose style Page
+// used by the SpaceX API, so both paginate the same way.
+func libraryPage[R, T any](raw model.LL2Page[R], options QueryOptions, convert func(R) T) Page[T] {
+	page := Page[T]{
+		Docs:        make([]T, 0, len(raw.Results)),
+		TotalDocs:   raw.Count,
+		Offset:      options.Offset,
+		Limit:       options.Limit,
+		Page:        options.Offset/options.Limit + 1,
+		TotalPages:  (raw.Count + options.Limit - 1) / options.Limit,
+		HasPrevPage: raw.Previous != nil,
+		HasNextPage: raw.Next != nil,
+	}
+	for _, result := range raw.Results {
+		page.Docs = append(page.Docs, convert(result))
+	}
+	if page.HasPrevPage {
+		prev := page.Page - 1
+		page.PrevPage = &prev
+	}
+	if page.HasNextPage {
+		next := page.Page + 1
+		page.NextPage = &next
+	}
+	return page
+}
+
+// LibraryQuery builds the query string for the Launch Library 2 agency, pad
+// and launcher list endpoints. The zero value lists everything.
+type LibraryQuery struct {
+	search  string
+	filters url.Values
+	options QueryOptions
+}
+
+func NewLibraryQuery() *LibraryQuery {
+	return &LibraryQuery{}
+}
+
+// Search matches documents whose name or abbreviation contains term.
+func (q *LibraryQuery) Search(term string) *LibraryQuery {
+	q.search = term
+	return q
+}
+
+// Where adds an endpoint specific filter, e.g. Where("country_code", "USA").
+func (q *LibraryQuery) Where(param, value string) *LibraryQuery {
+	if q.filters == nil {
+		q.filters = url.Values{}
+	}
+	q.filters.Set(param, value)
+	return q
+}
+
+func (q *LibraryQuery) Limit(n int) *LibraryQuery {
+	q.options.Limit = n
+	return q
+}
+
+func (q *LibraryQuery) Offset(n int) *LibraryQuery {
+	q.options.Offset = n
+	return q
+}
+
+func (q *LibraryQuery) Page(n int) *LibraryQuery {
+	q.options.Page = n
+	return q
+}
+
+func (q *LibraryQuery) Options() QueryOptions {
+	return q.options
+}
+
+// WithOptions returns a copy of q using options.
+func (q *LibraryQuery) WithOptions(options QueryOptions) *LibraryQuery {
+	clone := *q
+	clone.filters = url.Values{}
+	for param, values := range q.filters {
+		clone.filters[param] = append([]string(nil), values...)
+	}
+	clone.options = options
+	return &clone
+}
+
+func (q *LibraryQuery) params() url.Values {
+	params := url.Values{}
+	for param, values := range q.filters {
+		params[param] = append([]string(nil), values...)
+	}
+	if q.search != "" {
+		params.Set("search", q.search)
+	}
+	return params
+}
+
+// Encode returns the filter part of the query string, including the leading
+// "?", or "" when nothing is filtered. Paging is added by the client.
+func (q *LibraryQuery) Encode() string {
+	if q == nil {
+		return ""
+	}
+	params := q.params()
+	if len(params) == 0 {
+		return ""
+	}
+	return "?" + params.Encode()
+}
+
+// IterateLibrary walks every page of query through fetchPage, e.g. a
+// client's QueryAgencies method.
+func IterateLibrary[T any](ctx context.Context, query *LibraryQuery, fetchPage func(context.Context, *LibraryQuery) (Page[T], error)) iter.Seq2[T, error] {
+	return iteratePages(ctx, query.Options(), func(ctx context.Context, options QueryOptions) (Page[T], error) {
+		return fetchPage(ctx, query.WithOptions(options))
+	})
+}
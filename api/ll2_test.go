@@ -0,0 +1,134 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/MitiaRD/ReMarkable-cli/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLaunchLibraryQueryLaunches(t *testing.T) {
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2020, 12, 31, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		query    *LaunchQuery
+		path     string
+		expected string
+	}{
+		{
+			name:     "default query",
+			query:    NewLaunchQuery(),
+			path:     "/launch/",
+			expected: "limit=10",
+		},
+		{
+			name:     "previous failures in a date range",
+			query:    NewLaunchQuery().Upcoming(false).Success(false).Between(start, end).SortBy("date_utc", Descending).Limit(200),
+			path:     "/launch/previous/",
+			expected: "limit=100&net__gte=2020-01-01T00%3A00%3A00.000Z&net__lte=2020-12-31T00%3A00%3A00.000Z&ordering=-net&status__ids=4%2C7",
+		},
+		{
+			name:     "second page of upcoming launches",
+			query:    NewLaunchQuery().Upcoming(true).SortBy("flight_number", Ascending).SortBy("date_utc", Ascending).Limit(20).Page(2),
+			path:     "/launch/upcoming/",
+			expected: "limit=20&offset=20&ordering=net",
+		},
+		{
+			name:     "successes",
+			query:    NewLaunchQuery().Success(true).Offset(5),
+			path:     "/launch/",
+			expected: "limit=10&offset=5&status__ids=3",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, tt.path, r.URL.Path)
+				assert.Equal(t, tt.expected, r.URL.RawQuery)
+				io.WriteString(w, `{"count":1,"next":null,"previous":null,"results":[{"id":"abc","name":"Long March 5 | Tianwen-1","status":{"id":3},"launch_service_provider":{"name":"China Aerospace Science and Technology Corporation"},"rocket":{"configuration":{"id":466,"full_name":"Long March 5"}},"pad":{"id":136,"name":"Launch Complex 101"}}]}`)
+			}))
+			defer server.Close()
+
+			config := testConfig()
+			config.LL2BaseURL = server.URL
+			client := NewLaunchLibraryClient(config, testLogger())
+
+			page, err := client.QueryLaunches(context.Background(), tt.query)
+			require.NoError(t, err)
+			require.Len(t, page.Docs, 1)
+			assert.Equal(t, "Tianwen-1", page.Docs[0].Name)
+			assert.Equal(t, "466", page.Docs[0].RocketId)
+			assert.Equal(t, "Long March 5", page.Docs[0].Rocket.Name)
+			assert.Equal(t, 1, page.TotalDocs)
+			assert.False(t, page.HasNextPage)
+		})
+	}
+}
+
+func TestLaunchLibraryPagination(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Token secret-token", r.Header.Get("Authorization"))
+		requests = append(requests, r.URL.RequestURI())
+		switch r.URL.Query().Get("offset") {
+		case "":
+			fmt.Fprintf(w, `{"count":3,"next":"%s/config/launcher/?limit=100&offset=100","previous":null,"results":[{"id":1,"name":"Falcon 9"},{"id":2,"name":"Electron"}]}`, "http://"+r.Host)
+		default:
+			io.WriteString(w, `{"count":3,"next":null,"previous":"x","results":[{"id":3,"name":"Soyuz 2.1a"}]}`)
+		}
+	}))
+	defer server.Close()
+
+	config := testConfig()
+	config.LL2BaseURL = server.URL
+	config.LL2APIToken = "secret-token"
+	config.LL2RateLimit = 100
+	client := NewLaunchLibraryClient(config, testLogger())
+
+	rockets, err := client.GetRockets(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, rockets, 3)
+	assert.Equal(t, "Soyuz 2.1a", rockets["3"].Name)
+	assert.Equal(t, []string{
+		"/config/launcher/?limit=100",
+		"/config/launcher/?limit=100&offset=100",
+	}, requests)
+}
+
+func TestLaunchLibraryQueryAgencies(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/agencies/", r.URL.Path)
+		assert.Equal(t, "country_code=USA&limit=5&offset=5&search=space", r.URL.RawQuery)
+		assert.Empty(t, r.Header.Get("Authorization"), "anonymous requests carry no token")
+		io.WriteString(w, `{"count":12,"next":"next","previous":"prev","results":[{"id":121,"name":"SpaceX","abbrev":"SpX","type":"Commercial","country_code":"USA","founding_year":"2002"}]}`)
+	}))
+	defer server.Close()
+
+	config := testConfig()
+	config.LL2BaseURL = server.URL
+	client := NewLaunchLibraryClient(config, testLogger())
+
+	page, err := client.QueryAgencies(context.Background(), NewLibraryQuery().Search("space").Where("country_code", "USA").Limit(5).Page(2))
+	require.NoError(t, err)
+	assert.Equal(t, []model.Agency{{ID: "121", Name: "SpaceX", Abbrev: "SpX", Type: "Commercial", CountryCode: "USA", FoundingYear: "2002"}}, page.Docs)
+	assert.Equal(t, 2, page.Page)
+	assert.Equal(t, 3, page.TotalPages)
+	assert.Equal(t, 3, *page.NextPage)
+	assert.Equal(t, 1, *page.PrevPage)
+}
+
+func TestLibraryQueryEncode(t *testing.T) {
+	assert.Equal(t, "", (*LibraryQuery)(nil).Encode())
+	assert.Equal(t, "", NewLibraryQuery().Limit(5).Encode())
+	assert.Equal(t, "?country_code=USA&search=space", NewLibraryQuery().Search("space").Where("country_code", "USA").Page(2).Encode())
+}
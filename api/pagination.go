@@ -0,0 +1,98 @@
+package api
+
+import (
+	"context"
+	"iter"
+	"slices"
+)
+
+// DefaultLimit is the page size the SpaceX API uses when none is given.
+const DefaultLimit = 10
+
+// Page is one page of results from a SpaceX /query endpoint.
+type Page[T any] struct {
+	Docs        []T  `json:"docs"`
+	TotalDocs   int  `json:"totalDocs"`
+	Offset      int  `json:"offset"`
+	Limit       int  `json:"limit"`
+	TotalPages  int  `json:"totalPages"`
+	Page        int  `json:"page"`
+	HasPrevPage bool `json:"hasPrevPage"`
+	HasNextPage bool `json:"hasNextPage"`
+	PrevPage    *int `json:"prevPage"`
+	NextPage    *int `json:"nextPage"`
+}
+
+// iteratePages walks every page of a paginated query starting from options.
+// Queries that set an offset advance the offset; all others follow the
+// server's nextPage.
+func iteratePages[T any](ctx context.Context, options QueryOptions, fetchPage func(context.Context, QueryOptions) (Page[T], error)) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for {
+			page, err := fetchPage(ctx, options)
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+			for _, doc := range page.Docs {
+				if !yield(doc, nil) {
+					return
+				}
+			}
+			if !page.HasNextPage || len(page.Docs) == 0 {
+				return
+			}
+
+			switch {
+			case options.Offset > 0:
+				options.Offset += len(page.Docs)
+			case page.NextPage != nil:
+				options.Page = *page.NextPage
+			default:
+				options.Page = page.Page + 1
+			}
+		}
+	}
+}
+
+// Paginate cuts one page out of docs the way mongoose-paginate does: an
+// offset takes precedence over a page number.
+func Paginate[T any](docs []T, options QueryOptions) Page[T] {
+	limit := options.Limit
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+
+	page := max(options.Page, 1)
+	start := (page - 1) * limit
+	if options.Offset > 0 {
+		start = options.Offset
+		page = start/limit + 1
+	}
+	start = min(start, len(docs))
+	end := min(start+limit, len(docs))
+
+	result := Page[T]{
+		Docs:       slices.Clone(docs[start:end]),
+		TotalDocs:  len(docs),
+		Offset:     start,
+		Limit:      limit,
+		TotalPages: (len(docs) + limit - 1) / limit,
+		Page:       page,
+	}
+	if result.Docs == nil {
+		result.Docs = []T{}
+	}
+	if page > 1 {
+		prev := page - 1
+		result.HasPrevPage = true
+		result.PrevPage = &prev
+	}
+	if end < len(docs) {
+		next := page + 1
+		result.HasNextPage = true
+		result.NextPage = &next
+	}
+	return result
+}
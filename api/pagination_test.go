@@ -0,0 +1,91 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newPagedLaunchServer(t *testing.T, total, pageSize int, requests *[]map[string]interface{}) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		*requests = append(*requests, body)
+
+		options, _ := body["options"].(map[string]interface{})
+		start := 0
+		if offset, ok := options["offset"].(float64); ok {
+			start = int(offset)
+		} else if page, ok := options["page"].(float64); ok {
+			start = (int(page) - 1) * pageSize
+		}
+		end := min(start+pageSize, total)
+
+		docs := []map[string]interface{}{}
+		for i := start; i < end; i++ {
+			docs = append(docs, map[string]interface{}{"name": fmt.Sprintf("launch-%d", i)})
+		}
+		page := start/pageSize + 1
+		response := map[string]interface{}{
+			"docs":        docs,
+			"totalDocs":   total,
+			"limit":       pageSize,
+			"page":        page,
+			"hasNextPage": end < total,
+		}
+		if end < total {
+			response["nextPage"] = page + 1
+		}
+		json.NewEncoder(w).Encode(response)
+	}))
+}
+
+func TestIterateLaunchesWalksEveryPage(t *testing.T) {
+	var requests []map[string]interface{}
+	server := newPagedLaunchServer(t, 5, 2, &requests)
+	defer server.Close()
+
+	config := testConfig()
+	config.SpaceXBaseURL = server.URL
+	client := NewSpaceXClient(config, testLogger())
+
+	var names []string
+	for launch, err := range client.IterateLaunches(context.Background(), NewLaunchQuery().Limit(2)) {
+		require.NoError(t, err)
+		names = append(names, launch.Name)
+	}
+
+	assert.Equal(t, []string{"launch-0", "launch-1", "launch-2", "launch-3", "launch-4"}, names)
+	require.Len(t, requests, 3)
+	assert.Nil(t, requests[0]["options"].(map[string]interface{})["page"])
+	assert.Equal(t, float64(3), requests[2]["options"].(map[string]interface{})["page"])
+}
+
+func TestIterateLaunchesAdvancesOffset(t *testing.T) {
+	var requests []map[string]interface{}
+	server := newPagedLaunchServer(t, 5, 2, &requests)
+	defer server.Close()
+
+	config := testConfig()
+	config.SpaceXBaseURL = server.URL
+	client := NewSpaceXClient(config, testLogger())
+
+	var names []string
+	for launch, err := range client.IterateLaunches(context.Background(), NewLaunchQuery().Limit(2).Offset(1)) {
+		require.NoError(t, err)
+		names = append(names, launch.Name)
+		if len(names) == 3 {
+			break
+		}
+	}
+
+	assert.Equal(t, []string{"launch-1", "launch-2", "launch-3"}, names)
+	require.Len(t, requests, 2)
+	assert.Equal(t, float64(3), requests[1]["options"].(map[string]interface{})["offset"])
+}
@@ -0,0 +1,129 @@
+package api
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/MitiaRD/ReMarkable-cli/model"
+)
+
+// LaunchQuerier serves pages of launches matching a LaunchQuery.
+type LaunchQuerier interface {
+	QueryLaunches(ctx context.Context, query *LaunchQuery) (Page[model.Launch], error)
+}
+
+// LaunchProvider is a source of launches along with the rockets and
+// launchpads they refer to, keyed by ID. The launches command shows the
+// launches of every provider selected by the provider config key, and new
+// providers are added with RegisterProvider or, outside Go, as an
+// ExecProvider.
+//
+// A launch's RocketId and LaunchpadId refer to the provider's own rockets
+// and launchpads; the provider may also embed them in Rocket and Launchpad,
+// which saves the CLI from fetching them. Agency names who launched, and
+// LaunchLibraryID, when known, lets the CLI drop the same launch coming from
+// another provider. Provider is filled in with Name when left empty.
+//
+// Methods must be safe for concurrent use and should return ctx's error
+// once it is done.
+type LaunchProvider interface {
+	// QueryLaunches returns a page of the launches matching query. Providers
+	// apply as much of the query as their API supports, falling back to
+	// LaunchQuery.Matches, SortLaunches and Paginate for the rest.
+	LaunchQuerier
+	// Name identifies the provider, e.g. "spacex".
+	Name() string
+	GetRockets(ctx context.Context) (map[string]model.Rocket, error)
+	GetLaunchpads(ctx context.Context) (map[string]model.Launchpad, error)
+}
+
+var _ LaunchProvider = (*SpaceXProvider)(nil)
+
+// SpaceXProvider serves launches from a SpaceXAPI.
+type SpaceXProvider struct {
+	client SpaceXAPI
+}
+
+func NewSpaceXProvider(client SpaceXAPI) *SpaceXProvider {
+	return &SpaceXProvider{client: client}
+}
+
+func (p *SpaceXProvider) Name() string {
+	return model.ProviderSpaceX
+}
+
+func (p *SpaceXProvider) QueryLaunches(ctx context.Context, query *LaunchQuery) (Page[model.Launch], error) {
+	page, err := p.client.QueryLaunches(ctx, query)
+	for i := range page.Docs {
+		page.Docs[i].Agency = "SpaceX"
+	}
+	return page, err
+}
+
+func (p *SpaceXProvider) GetRockets(ctx context.Context) (map[string]model.Rocket, error) {
+	return p.client.GetAllRockets(ctx)
+}
+
+func (p *SpaceXProvider) GetLaunchpads(ctx context.Context) (map[string]model.Launchpad, error) {
+	return p.client.GetAllLaunchpads(ctx)
+}
+
+// sameLaunchTolerance is how close two launches by the same agency must be
+// to count as one launch when neither knows the other's ID.
+const sameLaunchTolerance = time.Minute
+
+// SameLaunch reports whether a and b describe the same launch from different
+// providers: either one carries the other's Launch Library ID, or the same
+// agency launched both at the same time.
+func SameLaunch(a, b model.Launch) bool {
+	if a.LaunchLibraryID != nil && b.LaunchLibraryID != nil {
+		return *a.LaunchLibraryID == *b.LaunchLibraryID
+	}
+	if a.Agency == "" || !strings.EqualFold(a.Agency, b.Agency) {
+		return false
+	}
+	return a.Date.Sub(b.Date).Abs() <= sameLaunchTolerance
+}
+
+// MergeLaunches combines the launches of several providers, dropping any
+// launch already seen from an earlier source, and orders the result by sort.
+func MergeLaunches(sort []SortField, sources ...[]model.Launch) []model.Launch {
+	merged := []model.Launch{}
+	for _, launches := range sources {
+		for _, launch := range launches {
+			if !slices.ContainsFunc(merged, func(seen model.Launch) bool { return SameLaunch(seen, launch) }) {
+				merged = append(merged, launch)
+			}
+		}
+	}
+	SortLaunches(merged, sort)
+	return merged
+}
+
+// SortLaunches orders launches by date_utc, flight_number and name sort
+// keys. Unknown fields are ignored.
+func SortLaunches(launches []model.Launch, sort []SortField) {
+	slices.SortStableFunc(launches, func(a, b model.Launch) int {
+		for _, field := range sort {
+			var c int
+			switch field.Field {
+			case "date_utc":
+				c = a.Date.Compare(b.Date)
+			case "flight_number":
+				c = cmp.Compare(a.FlightNumber, b.FlightNumber)
+			case "name":
+				c = strings.Compare(a.Name, b.Name)
+			}
+			if field.Order == Descending {
+				c = -c
+			}
+			if c != 0 {
+				return c
+			}
+		}
+		return 0
+	})
+}
@@ -0,0 +1,82 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/MitiaRD/ReMarkable-cli/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeLaunches(t *testing.T) {
+	launchDate := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	ll2ID := "0d779392-1a36-4c1e-b0b8-ec11e3031ee6"
+
+	spaceX := []model.Launch{
+		{ID: "spacex-crs", Name: "CRS-30", Date: launchDate, Agency: "SpaceX", LaunchLibraryID: &ll2ID},
+		{ID: "spacex-starlink", Name: "Starlink 6-41", Date: launchDate.Add(48 * time.Hour), Agency: "SpaceX"},
+	}
+	ll2 := []model.Launch{
+		{ID: ll2ID, Name: "SpX CRS-30", Date: launchDate, Agency: "SpaceX", LaunchLibraryID: &ll2ID},
+		{ID: "ll2-starlink", Name: "Starlink Group 6-41", Date: launchDate.Add(48*time.Hour + 30*time.Second), Agency: "SpaceX"},
+		{ID: "ll2-electron", Name: "Owl Night Long", Date: launchDate.Add(24 * time.Hour), Agency: "Rocket Lab"},
+		{ID: "ll2-soyuz", Name: "Progress MS-26", Date: launchDate.Add(48 * time.Hour), Agency: "Russian Federal Space Agency (ROSCOSMOS)"},
+	}
+
+	merged := MergeLaunches([]SortField{{Field: "date_utc", Order: Ascending}}, spaceX, ll2)
+
+	var ids []string
+	for _, launch := range merged {
+		ids = append(ids, launch.ID)
+	}
+	assert.Equal(t, []string{"spacex-crs", "ll2-electron", "spacex-starlink", "ll2-soyuz"}, ids)
+}
+
+func TestSameLaunch(t *testing.T) {
+	launchDate := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	first, second := "first", "second"
+
+	tests := []struct {
+		name     string
+		a, b     model.Launch
+		expected bool
+	}{
+		{
+			name:     "same launch library ID",
+			a:        model.Launch{LaunchLibraryID: &first, Date: launchDate},
+			b:        model.Launch{LaunchLibraryID: &first, Date: launchDate.Add(time.Hour)},
+			expected: true,
+		},
+		{
+			name:     "different launch library IDs at the same time",
+			a:        model.Launch{LaunchLibraryID: &first, Date: launchDate, Agency: "SpaceX"},
+			b:        model.Launch{LaunchLibraryID: &second, Date: launchDate, Agency: "SpaceX"},
+			expected: false,
+		},
+		{
+			name:     "same agency within a minute",
+			a:        model.Launch{LaunchLibraryID: &first, Date: launchDate, Agency: "SpaceX"},
+			b:        model.Launch{Date: launchDate.Add(-time.Minute), Agency: "spacex"},
+			expected: true,
+		},
+		{
+			name:     "different agencies at the same time",
+			a:        model.Launch{Date: launchDate, Agency: "SpaceX"},
+			b:        model.Launch{Date: launchDate, Agency: "Rocket Lab"},
+			expected: false,
+		},
+		{
+			name:     "unknown agency",
+			a:        model.Launch{Date: launchDate},
+			b:        model.Launch{Date: launchDate},
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, SameLaunch(tt.a, tt.b))
+			assert.Equal(t, tt.expected, SameLaunch(tt.b, tt.a))
+		})
+	}
+}
@@ -0,0 +1,403 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/MitiaRD/ReMarkable-cli/model"
+)
+
+const queryTimeLayout = "2006-01-02T15:04:05.000Z"
+
+type SortOrder string
+
+const (
+	Ascending  SortOrder = "asc"
+	Descending SortOrder = "desc"
+)
+
+type SortField struct {
+	Field string
+	Order SortOrder
+}
+
+// QueryOptions holds the paging, sorting and population options shared by
+// every SpaceX /query endpoint.
+type QueryOptions struct {
+	Sort     []SortField
+	Limit    int
+	Offset   int
+	Page     int
+	Populate []string
+}
+
+func (o QueryOptions) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	write := func(key string, value any) error {
+		if buf.Len() > 1 {
+			buf.WriteByte(',')
+		}
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		buf.WriteString(`"` + key + `":`)
+		buf.Write(encoded)
+		return nil
+	}
+
+	if len(o.Sort) > 0 {
+		// Sort keys are order sensitive, so the object is written by hand.
+		var sort bytes.Buffer
+		sort.WriteByte('{')
+		for i, field := range o.Sort {
+			if i > 0 {
+				sort.WriteByte(',')
+			}
+			key, _ := json.Marshal(field.Field)
+			sort.Write(key)
+			sort.WriteString(`:"` + string(field.Order) + `"`)
+		}
+		sort.WriteByte('}')
+		if err := write("sort", json.RawMessage(sort.Bytes())); err != nil {
+			return nil, err
+		}
+	}
+	if o.Limit > 0 {
+		if err := write("limit", o.Limit); err != nil {
+			return nil, err
+		}
+	}
+	if o.Offset > 0 {
+		if err := write("offset", o.Offset); err != nil {
+			return nil, err
+		}
+	}
+	if o.Page > 0 {
+		if err := write("page", o.Page); err != nil {
+			return nil, err
+		}
+	}
+	if len(o.Populate) > 0 {
+		if err := write("populate", o.Populate); err != nil {
+			return nil, err
+		}
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// LaunchQuery builds a request body for the SpaceX /launches/query endpoint.
+// The zero value matches every launch; builder methods return the query so
+// calls can be chained.
+type LaunchQuery struct {
+	dateFrom   *time.Time
+	dateTo     *time.Time
+	success    *bool
+	upcoming   *bool
+	rockets    []string
+	launchpads []string
+	crew       []string
+	flightFrom *int
+	flightTo   *int
+	options    QueryOptions
+}
+
+func NewLaunchQuery() *LaunchQuery {
+	return &LaunchQuery{}
+}
+
+// DateFrom restricts launches to those on or after t.
+func (q *LaunchQuery) DateFrom(t time.Time) *LaunchQuery {
+	q.dateFrom = &t
+	return q
+}
+
+// DateTo restricts launches to those on or before t.
+func (q *LaunchQuery) DateTo(t time.Time) *LaunchQuery {
+	q.dateTo = &t
+	return q
+}
+
+func (q *LaunchQuery) Between(start, end time.Time) *LaunchQuery {
+	return q.DateFrom(start).DateTo(end)
+}
+
+func (q *LaunchQuery) Success(success bool) *LaunchQuery {
+	q.success = &success
+	return q
+}
+
+func (q *LaunchQuery) Upcoming(upcoming bool) *LaunchQuery {
+	q.upcoming = &upcoming
+	return q
+}
+
+func (q *LaunchQuery) Rocket(ids ...string) *LaunchQuery {
+	q.rockets = append(q.rockets, ids...)
+	return q
+}
+
+func (q *LaunchQuery) Launchpad(ids ...string) *LaunchQuery {
+	q.launchpads = append(q.launchpads, ids...)
+	return q
+}
+
+func (q *LaunchQuery) Crew(ids ...string) *LaunchQuery {
+	q.crew = append(q.crew, ids...)
+	return q
+}
+
+// FlightNumberFrom restricts launches to flight numbers >= n.
+func (q *LaunchQuery) FlightNumberFrom(n int) *LaunchQuery {
+	q.flightFrom = &n
+	return q
+}
+
+// FlightNumberTo restricts launches to flight numbers <= n.
+func (q *LaunchQuery) FlightNumberTo(n int) *LaunchQuery {
+	q.flightTo = &n
+	return q
+}
+
+func (q *LaunchQuery) FlightNumbers(from, to int) *LaunchQuery {
+	return q.FlightNumberFrom(from).FlightNumberTo(to)
+}
+
+// SortBy appends a sort key; earlier keys take precedence.
+func (q *LaunchQuery) SortBy(field string, order SortOrder) *LaunchQuery {
+	q.options.Sort = append(q.options.Sort, SortField{Field: field, Order: order})
+	return q
+}
+
+func (q *LaunchQuery) Limit(n int) *LaunchQuery {
+	q.options.Limit = n
+	return q
+}
+
+func (q *LaunchQuery) Offset(n int) *LaunchQuery {
+	q.options.Offset = n
+	return q
+}
+
+func (q *LaunchQuery) Page(n int) *LaunchQuery {
+	q.options.Page = n
+	return q
+}
+
+// Populate asks the server to embed the referenced documents at paths.
+func (q *LaunchQuery) Populate(paths ...string) *LaunchQuery {
+	q.options.Populate = append(q.options.Populate, paths...)
+	return q
+}
+
+func (q *LaunchQuery) Options() QueryOptions {
+	return q.options
+}
+
+// WithOptions returns a copy of q using options.
+func (q *LaunchQuery) WithOptions(options QueryOptions) *LaunchQuery {
+	clone := q.Clone()
+	clone.options = options
+	return clone
+}
+
+func (q *LaunchQuery) Clone() *LaunchQuery {
+	clone := *q
+	clone.rockets = slices.Clone(q.rockets)
+	clone.launchpads = slices.Clone(q.launchpads)
+	clone.crew = slices.Clone(q.crew)
+	clone.options.Sort = slices.Clone(q.options.Sort)
+	clone.options.Populate = slices.Clone(q.options.Populate)
+	return &clone
+}
+
+func (q *LaunchQuery) MarshalJSON() ([]byte, error) {
+	filter := map[string]any{}
+
+	if q.dateFrom != nil || q.dateTo != nil {
+		dates := map[string]string{}
+		if q.dateFrom != nil {
+			dates["$gte"] = q.dateFrom.UTC().Format(queryTimeLayout)
+		}
+		if q.dateTo != nil {
+			dates["$lte"] = q.dateTo.UTC().Format(queryTimeLayout)
+		}
+		filter["date_utc"] = dates
+	}
+	if q.success != nil {
+		filter["success"] = *q.success
+	}
+	if q.upcoming != nil {
+		filter["upcoming"] = *q.upcoming
+	}
+	if match := matchAny(q.rockets); match != nil {
+		filter["rocket"] = match
+	}
+	if match := matchAny(q.launchpads); match != nil {
+		filter["launchpad"] = match
+	}
+	if match := matchAny(q.crew); match != nil {
+		filter["crew"] = match
+	}
+	if q.flightFrom != nil || q.flightTo != nil {
+		flights := map[string]int{}
+		if q.flightFrom != nil {
+			flights["$gte"] = *q.flightFrom
+		}
+		if q.flightTo != nil {
+			flights["$lte"] = *q.flightTo
+		}
+		filter["flight_number"] = flights
+	}
+
+	return json.Marshal(struct {
+		Query   map[string]any `json:"query"`
+		Options QueryOptions   `json:"options"`
+	}{
+		Query:   filter,
+		Options: q.options,
+	})
+}
+
+// Matches reports whether launch satisfies the query filter, mirroring what
+// the server would return. Options such as sorting and paging are ignored.
+func (q *LaunchQuery) Matches(launch model.Launch) bool {
+	if q.dateFrom != nil && launch.Date.Before(*q.dateFrom) {
+		return false
+	}
+	if q.dateTo != nil && launch.Date.After(*q.dateTo) {
+		return false
+	}
+	if q.success != nil && (launch.Success == nil || *launch.Success != *q.success) {
+		return false
+	}
+	if q.upcoming != nil && launch.Upcoming != *q.upcoming {
+		return false
+	}
+	if len(q.rockets) > 0 && !slices.Contains(q.rockets, launch.RocketId) {
+		return false
+	}
+	if len(q.launchpads) > 0 && !slices.Contains(q.launchpads, launch.LaunchpadId) {
+		return false
+	}
+	if len(q.crew) > 0 && !slices.ContainsFunc(launch.Crew, func(id string) bool {
+		return slices.Contains(q.crew, id)
+	}) {
+		return false
+	}
+	if q.flightFrom != nil && launch.FlightNumber < *q.flightFrom {
+		return false
+	}
+	if q.flightTo != nil && launch.FlightNumber > *q.flightTo {
+		return false
+	}
+	return true
+}
+
+func matchAny(ids []string) any {
+	switch len(ids) {
+	case 0:
+		return nil
+	case 1:
+		return ids[0]
+	default:
+		return map[string][]string{"$in": ids}
+	}
+}
+
+// CollectionQuery builds a request body for the /query endpoints of the
+// payloads, cores, capsules, ships and landpads collections. Filters are
+// plain field matches; the zero value matches every document.
+type CollectionQuery struct {
+	filter  map[string]any
+	options QueryOptions
+}
+
+func NewCollectionQuery() *CollectionQuery {
+	return &CollectionQuery{filter: map[string]any{}}
+}
+
+// Where restricts documents to those whose field equals value. For array
+// fields, such as a core's launches, the array must contain value.
+func (q *CollectionQuery) Where(field string, value any) *CollectionQuery {
+	if q.filter == nil {
+		q.filter = map[string]any{}
+	}
+	q.filter[field] = value
+	return q
+}
+
+// In restricts documents to those whose field equals any of values.
+func (q *CollectionQuery) In(field string, values ...string) *CollectionQuery {
+	if q.filter == nil {
+		q.filter = map[string]any{}
+	}
+	// An empty $in matches nothing, like on the server.
+	q.filter[field] = map[string][]string{"$in": append([]string{}, values...)}
+	return q
+}
+
+// SortBy appends a sort key; earlier keys take precedence.
+func (q *CollectionQuery) SortBy(field string, order SortOrder) *CollectionQuery {
+	q.options.Sort = append(q.options.Sort, SortField{Field: field, Order: order})
+	return q
+}
+
+func (q *CollectionQuery) Limit(n int) *CollectionQuery {
+	q.options.Limit = n
+	return q
+}
+
+func (q *CollectionQuery) Offset(n int) *CollectionQuery {
+	q.options.Offset = n
+	return q
+}
+
+func (q *CollectionQuery) Page(n int) *CollectionQuery {
+	q.options.Page = n
+	return q
+}
+
+// Populate asks the server to embed the referenced documents at paths.
+func (q *CollectionQuery) Populate(paths ...string) *CollectionQuery {
+	q.options.Populate = append(q.options.Populate, paths...)
+	return q
+}
+
+func (q *CollectionQuery) Options() QueryOptions {
+	return q.options
+}
+
+// WithOptions returns a copy of q using options.
+func (q *CollectionQuery) WithOptions(options QueryOptions) *CollectionQuery {
+	clone := q.Clone()
+	clone.options = options
+	return clone
+}
+
+func (q *CollectionQuery) Clone() *CollectionQuery {
+	clone := *q
+	clone.filter = maps.Clone(q.filter)
+	clone.options.Sort = slices.Clone(q.options.Sort)
+	clone.options.Populate = slices.Clone(q.options.Populate)
+	return &clone
+}
+
+func (q *CollectionQuery) MarshalJSON() ([]byte, error) {
+	filter := q.filter
+	if filter == nil {
+		filter = map[string]any{}
+	}
+	return json.Marshal(struct {
+		Query   map[string]any `json:"query"`
+		Options QueryOptions   `json:"options"`
+	}{
+		Query:   filter,
+		Options: q.options,
+	})
+}
@@ -0,0 +1,118 @@
+package api
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLaunchQueryMarshalJSON(t *testing.T) {
+	jan1 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	jan31 := time.Date(2024, 1, 31, 23, 59, 59, 999e6, time.UTC)
+
+	tests := []struct {
+		name     string
+		query    *LaunchQuery
+		expected string
+	}{
+		{
+			name:     "empty query",
+			query:    NewLaunchQuery(),
+			expected: `{"query":{},"options":{}}`,
+		},
+		{
+			name:     "date range",
+			query:    NewLaunchQuery().Between(jan1, jan31),
+			expected: `{"query":{"date_utc":{"$gte":"2024-01-01T00:00:00.000Z","$lte":"2024-01-31T23:59:59.999Z"}},"options":{}}`,
+		},
+		{
+			name:     "start only",
+			query:    NewLaunchQuery().DateFrom(jan1),
+			expected: `{"query":{"date_utc":{"$gte":"2024-01-01T00:00:00.000Z"}},"options":{}}`,
+		},
+		{
+			name:     "end only in another time zone",
+			query:    NewLaunchQuery().DateTo(jan1.In(time.FixedZone("CET", 3600))),
+			expected: `{"query":{"date_utc":{"$lte":"2024-01-01T00:00:00.000Z"}},"options":{}}`,
+		},
+		{
+			name:     "success and upcoming",
+			query:    NewLaunchQuery().Success(true).Upcoming(false),
+			expected: `{"query":{"success":true,"upcoming":false},"options":{}}`,
+		},
+		{
+			name:     "single rocket and several launchpads",
+			query:    NewLaunchQuery().Rocket("falcon9").Launchpad("slc40", "lc39a"),
+			expected: `{"query":{"rocket":"falcon9","launchpad":{"$in":["slc40","lc39a"]}},"options":{}}`,
+		},
+		{
+			name:     "crew",
+			query:    NewLaunchQuery().Crew("bob", "doug"),
+			expected: `{"query":{"crew":{"$in":["bob","doug"]}},"options":{}}`,
+		},
+		{
+			name:     "flight number range",
+			query:    NewLaunchQuery().FlightNumbers(10, 20),
+			expected: `{"query":{"flight_number":{"$gte":10,"$lte":20}},"options":{}}`,
+		},
+		{
+			name:     "open ended flight number range",
+			query:    NewLaunchQuery().FlightNumberFrom(100),
+			expected: `{"query":{"flight_number":{"$gte":100}},"options":{}}`,
+		},
+		{
+			name: "options",
+			query: NewLaunchQuery().
+				SortBy("date_utc", Descending).
+				Limit(10).
+				Offset(5).
+				Page(2).
+				Populate("rocket", "launchpad"),
+			expected: `{"query":{},"options":{"sort":{"date_utc":"desc"},"limit":10,"offset":5,"page":2,"populate":["rocket","launchpad"]}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := json.Marshal(tt.query)
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.expected, string(actual))
+		})
+	}
+}
+
+func TestLaunchQuerySortKeepsOrder(t *testing.T) {
+	query := NewLaunchQuery().SortBy("flight_number", Ascending).SortBy("date_utc", Descending)
+
+	actual, err := json.Marshal(query.Options())
+	require.NoError(t, err)
+	assert.Equal(t, `{"sort":{"flight_number":"asc","date_utc":"desc"}}`, string(actual))
+}
+
+func TestLaunchQueryWithOptionsLeavesOriginal(t *testing.T) {
+	query := NewLaunchQuery().Rocket("falcon9").Limit(10)
+
+	options := query.Options()
+	options.Page = 3
+	next := query.WithOptions(options).Rocket("falconheavy")
+
+	assert.Equal(t, 0, query.Options().Page)
+	assert.Equal(t, 3, next.Options().Page)
+	assert.Equal(t, []string{"falcon9"}, query.rockets)
+}
+
+func TestCollectionQuery(t *testing.T) {
+	query := NewCollectionQuery().
+		Where("status", "active").
+		In("launches", "l1", "l2").
+		SortBy("serial", Ascending).
+		Limit(5)
+
+	body, err := json.Marshal(query)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"query":{"status":"active","launches":{"$in":["l1","l2"]}},"options":{"sort":{"serial":"asc"},"limit":5}}`, string(body))
+
+}
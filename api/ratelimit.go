@@ -0,0 +1,122 @@
+package api
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// quotaWindow is the period NASA's X-RateLimit-Limit applies to.
+const quotaWindow = time.Hour
+
+// RateLimitStatus is the quota last reported by the server.
+type RateLimitStatus struct {
+	Known     bool
+	Limit     int
+	Remaining int
+}
+
+// RateLimiter is a token bucket for one provider. It starts at a configured
+// rate and tightens itself from the X-RateLimit-Limit / X-RateLimit-Remaining
+// headers so that calls slow down before the server starts answering 429.
+type RateLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+	status RateLimitStatus
+	now    func() time.Time
+}
+
+// NewRateLimiter allows perSecond requests on average with bursts of up to
+// burst requests.
+func NewRateLimiter(perSecond float64, burst int) *RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &RateLimiter{
+		rate:   perSecond,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		now:    time.Now,
+	}
+}
+
+// Wait blocks until a request may be sent or ctx is done.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	for {
+		delay := l.reserve()
+		if delay == 0 {
+			return nil
+		}
+		if err := sleep(ctx, delay); err != nil {
+			return err
+		}
+	}
+}
+
+// reserve takes a token if one is available, otherwise it returns how long
+// until the next token is due.
+func (l *RateLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	if !l.last.IsZero() {
+		l.tokens = math.Min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	}
+	l.last = now
+
+	if l.tokens >= 1 {
+		l.tokens--
+		return 0
+	}
+	if l.rate <= 0 {
+		return time.Second
+	}
+	return time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+}
+
+// Observe updates the bucket from the quota headers of a response.
+func (l *RateLimiter) Observe(header http.Header) {
+	limit, limitErr := strconv.Atoi(header.Get("X-RateLimit-Limit"))
+	remaining, remainingErr := strconv.Atoi(header.Get("X-RateLimit-Remaining"))
+	if limitErr != nil || remainingErr != nil {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.status = RateLimitStatus{Known: true, Limit: limit, Remaining: remaining}
+	if float64(remaining) < l.tokens {
+		l.tokens = float64(remaining)
+	}
+	// Once less than a tenth of the quota is left, spread what remains over
+	// the quota window instead of spending it in a burst.
+	if limit > 0 && remaining < limit/10 {
+		l.rate = math.Min(l.rate, float64(limit)/quotaWindow.Seconds())
+	}
+}
+
+func (l *RateLimiter) Status() RateLimitStatus {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.status
+}
+
+// sleep waits for d or until ctx is done, whichever comes first.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
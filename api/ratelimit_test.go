@@ -0,0 +1,71 @@
+package api
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimiterBucket(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	limiter := NewRateLimiter(2, 2)
+	limiter.now = func() time.Time { return now }
+
+	assert.Zero(t, limiter.reserve())
+	assert.Zero(t, limiter.reserve())
+	assert.Equal(t, 500*time.Millisecond, limiter.reserve())
+
+	now = now.Add(500 * time.Millisecond)
+	assert.Zero(t, limiter.reserve())
+}
+
+func TestRateLimiterObservesQuotaHeaders(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	limiter := NewRateLimiter(10, 10)
+	limiter.now = func() time.Time { return now }
+
+	limiter.Observe(http.Header{"X-Ratelimit-Limit": {"1000"}, "X-Ratelimit-Remaining": {"950"}})
+	assert.Equal(t, RateLimitStatus{Known: true, Limit: 1000, Remaining: 950}, limiter.Status())
+	assert.Equal(t, float64(10), limiter.rate)
+
+	// Nearly exhausted: the bucket drains to the remaining quota and refills
+	// at the hourly rate.
+	limiter.Observe(http.Header{"X-Ratelimit-Limit": {"30"}, "X-Ratelimit-Remaining": {"1"}})
+	assert.Zero(t, limiter.reserve())
+	assert.Equal(t, 2*time.Minute, limiter.reserve())
+
+	limiter.Observe(http.Header{"X-Ratelimit-Limit": {"garbage"}})
+	assert.Equal(t, 1, limiter.Status().Remaining)
+}
+
+func TestRequesterReportsQuota(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "40")
+		w.Header().Set("X-RateLimit-Remaining", "39")
+		io.WriteString(w, `{"element_count":0}`)
+	}))
+	defer server.Close()
+
+	config := testConfig()
+	config.NASABaseURL = server.URL
+	client := NewNASAClient(config, testLogger())
+
+	_, err := client.GetAsteroids(context.Background(), "?start_date=2024-01-01&end_date=2024-01-01")
+	require.NoError(t, err)
+	assert.Equal(t, RateLimitStatus{Known: true, Limit: 40, Remaining: 39}, client.Quota())
+}
+
+func TestRateLimiterWaitHonoursContext(t *testing.T) {
+	limiter := NewRateLimiter(0.001, 1)
+	require.NoError(t, limiter.Wait(context.Background()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, limiter.Wait(ctx), context.DeadlineExceeded)
+}
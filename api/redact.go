@@ -0,0 +1,199 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+const redacted = "REDACTED"
+
+// credentialParams are query parameters that carry credentials.
+var credentialParams = []string{"api_key"}
+
+var credentialParamPattern = regexp.MustCompile(`(?i)\b(api_key=)[^&\s"'\\]+`)
+
+// authorizationPattern matches the credentials of an Authorization header
+// written out as "Authorization: Token abc", including in a printed
+// http.Header.
+var authorizationPattern = regexp.MustCompile(`(?i)\b(authorization["']?\s*[:=]\s*["'\[]?(?:token|bearer|basic)\s+)[^\s"'&\]]+`)
+
+// minSecretLength is the shortest secret masked wherever it appears. Shorter
+// values, such as a one letter test key, would mangle unrelated text, so
+// they are only masked where credentials are expected.
+const minSecretLength = 8
+
+// Authenticator adds credentials to an outgoing request. Credentials are
+// attached only after caching and logging decisions have been made, so they
+// never become part of cache keys or log lines.
+type Authenticator interface {
+	Authenticate(req *http.Request)
+	// Secrets lists the credential values to mask wherever they might leak.
+	Secrets() []string
+}
+
+// APIKeyAuth sends a key as a query parameter, as api.nasa.gov expects.
+type APIKeyAuth struct {
+	Param string
+	Key   string
+}
+
+func (a APIKeyAuth) Authenticate(req *http.Request) {
+	query := req.URL.Query()
+	query.Set(a.Param, a.Key)
+	req.URL.RawQuery = query.Encode()
+}
+
+func (a APIKeyAuth) Secrets() []string {
+	if a.Key == "" {
+		return nil
+	}
+	return []string{a.Key}
+}
+
+// TokenAuth sends a token in the Authorization header, as Launch Library 2
+// expects. An empty token leaves requests anonymous.
+type TokenAuth struct {
+	Token string
+}
+
+func (a TokenAuth) Authenticate(req *http.Request) {
+	if a.Token != "" {
+		req.Header.Set("Authorization", "Token "+a.Token)
+	}
+}
+
+func (a TokenAuth) Secrets() []string {
+	if a.Token == "" {
+		return nil
+	}
+	return []string{a.Token}
+}
+
+// Redact masks credential query parameters, Authorization header values and
+// any of the given secret values in s. Secrets shorter than minSecretLength
+// are left to the first two.
+func Redact(s string, secrets ...string) string {
+	s = credentialParamPattern.ReplaceAllString(s, "${1}"+redacted)
+	s = authorizationPattern.ReplaceAllString(s, "${1}"+redacted)
+	for _, secret := range secrets {
+		if len(secret) >= minSecretLength {
+			s = strings.ReplaceAll(s, secret, redacted)
+		}
+	}
+	return s
+}
+
+// redactURL replaces credential query parameters with a placeholder while
+// keeping the URL well formed.
+func redactURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return Redact(rawURL)
+	}
+	query := u.Query()
+	changed := false
+	for _, param := range credentialParams {
+		if query.Has(param) {
+			query.Set(param, redacted)
+			changed = true
+		}
+	}
+	if !changed {
+		return rawURL
+	}
+	u.RawQuery = query.Encode()
+	return u.String()
+}
+
+// redactedError hides credentials in an error's message while keeping the
+// original reachable through errors.Is / errors.As.
+type redactedError struct {
+	msg string
+	err error
+}
+
+func (e *redactedError) Error() string { return e.msg }
+func (e *redactedError) Unwrap() error { return e.err }
+
+func redactError(err error, secrets ...string) error {
+	if err == nil {
+		return nil
+	}
+	// http.Client returns *url.Error carrying the full request URL; rebuild
+	// it so callers using errors.As see a clean URL too.
+	if urlErr, ok := err.(*url.Error); ok {
+		return &url.Error{
+			Op:  urlErr.Op,
+			URL: Redact(redactURL(urlErr.URL), secrets...),
+			Err: redactError(urlErr.Err, secrets...),
+		}
+	}
+	msg := Redact(err.Error(), secrets...)
+	if msg == err.Error() {
+		return err
+	}
+	return &redactedError{msg: msg, err: err}
+}
+
+// RedactingHandler masks credentials in log messages and attribute values
+// before passing records on to the wrapped handler.
+type RedactingHandler struct {
+	next    slog.Handler
+	secrets []string
+}
+
+func NewRedactingHandler(next slog.Handler, secrets ...string) *RedactingHandler {
+	return &RedactingHandler{next: next, secrets: secrets}
+}
+
+func (h *RedactingHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+func (h *RedactingHandler) Handle(ctx context.Context, record slog.Record) error {
+	clean := slog.NewRecord(record.Time, record.Level, Redact(record.Message, h.secrets...), record.PC)
+	record.Attrs(func(attr slog.Attr) bool {
+		clean.AddAttrs(h.redactAttr(attr))
+		return true
+	})
+	return h.next.Handle(ctx, clean)
+}
+
+func (h *RedactingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	clean := make([]slog.Attr, len(attrs))
+	for i, attr := range attrs {
+		clean[i] = h.redactAttr(attr)
+	}
+	return &RedactingHandler{next: h.next.WithAttrs(clean), secrets: h.secrets}
+}
+
+func (h *RedactingHandler) WithGroup(name string) slog.Handler {
+	return &RedactingHandler{next: h.next.WithGroup(name), secrets: h.secrets}
+}
+
+func (h *RedactingHandler) redactAttr(attr slog.Attr) slog.Attr {
+	value := attr.Value.Resolve()
+	switch value.Kind() {
+	case slog.KindString:
+		return slog.String(attr.Key, Redact(value.String(), h.secrets...))
+	case slog.KindAny:
+		if err, ok := value.Any().(error); ok {
+			return slog.String(attr.Key, Redact(err.Error(), h.secrets...))
+		}
+		return slog.String(attr.Key, Redact(fmt.Sprint(value.Any()), h.secrets...))
+	case slog.KindGroup:
+		group := value.Group()
+		clean := make([]any, len(group))
+		for i, member := range group {
+			clean[i] = h.redactAttr(member)
+		}
+		return slog.Group(attr.Key, clean...)
+	default:
+		return attr
+	}
+}
@@ -0,0 +1,149 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testAPIKey = "s3cr3t-nasa-key"
+
+func TestNASAClientSendsKeyWithoutLeakingIt(t *testing.T) {
+	var seen []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = append(seen, r.URL.Query().Get("api_key"))
+		io.WriteString(w, `{"element_count":1,"links":{"self":"`+r.URL.String()+`"}}`)
+	}))
+	defer server.Close()
+
+	config := testConfig()
+	config.NASABaseURL = server.URL
+	config.NASAAPIKey = testAPIKey
+	cache, err := NewCache(t.TempDir(), map[CacheClass]time.Duration{CacheNASA: time.Hour})
+	require.NoError(t, err)
+
+	client := NewNASAClient(config, testLogger(), WithCache(cache, false))
+	_, err = client.GetAsteroids(context.Background(), "?start_date=2024-01-01&end_date=2024-01-01")
+	require.NoError(t, err)
+	assert.Equal(t, []string{testAPIKey}, seen)
+
+	entries, err := cache.Entries()
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.NotContains(t, entries[0].URL, testAPIKey)
+	assert.NotContains(t, entries[0].Key, testAPIKey)
+	assert.Equal(t, CacheKey(http.MethodGet, server.URL+"/neo/rest/v1/feed?start_date=2024-01-01&end_date=2024-01-01", nil), entries[0].Key)
+
+	files, err := filepath.Glob(filepath.Join(cache.Dir(), "*"))
+	require.NoError(t, err)
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		require.NoError(t, err)
+		assert.NotContains(t, string(data), testAPIKey)
+	}
+}
+
+func TestNASAClientErrorsDoNotLeakKey(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+	}{
+		{
+			name: "status error echoing the request",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusForbidden)
+				io.WriteString(w, `{"error":"bad request `+r.URL.String()+`"}`)
+			},
+		},
+		{
+			name: "transport error",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				conn, _, _ := w.(http.Hijacker).Hijack()
+				conn.Close()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(tt.handler)
+			defer server.Close()
+
+			config := testConfig()
+			config.NASABaseURL = server.URL
+			config.NASAAPIKey = testAPIKey
+
+			var logs bytes.Buffer
+			logger := slog.New(slog.NewTextHandler(&logs, nil))
+			client := NewNASAClient(config, logger)
+			_, err := client.GetAsteroids(context.Background(), "?start_date=2024-01-01&end_date=2024-01-01")
+			require.Error(t, err)
+			assert.NotContains(t, err.Error(), testAPIKey)
+			assert.NotContains(t, logs.String(), testAPIKey)
+		})
+	}
+}
+
+func TestRedactErrorKeepsCause(t *testing.T) {
+	cause := errors.New("dial tcp: lookup api.nasa.gov?api_key=" + testAPIKey)
+	err := redactError(cause, testAPIKey)
+	assert.Equal(t, "dial tcp: lookup api.nasa.gov?api_key=REDACTED", err.Error())
+	assert.ErrorIs(t, err, cause)
+}
+
+func TestRedactingHandler(t *testing.T) {
+	var out bytes.Buffer
+	logger := slog.New(NewRedactingHandler(slog.NewTextHandler(&out, nil), testAPIKey))
+
+	logger.With("key", testAPIKey).Info("fetching https://api.nasa.gov/neo?api_key="+testAPIKey,
+		"error", errors.New("failed with "+testAPIKey),
+		slog.Group("request", "url", "https://api.nasa.gov/neo?api_key=abc"))
+
+	assert.NotContains(t, out.String(), testAPIKey)
+	assert.NotContains(t, out.String(), "api_key=abc")
+	assert.Contains(t, out.String(), "api_key=REDACTED")
+}
+
+func TestRedact(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		secrets []string
+		want    string
+	}{
+		{
+			name:    "secret value",
+			in:      "failed with " + testAPIKey,
+			secrets: []string{testAPIKey},
+			want:    "failed with REDACTED",
+		},
+		{
+			name:    "short secret only in credential params",
+			in:      "GET https://api.spacexdata.com/v4/fixture?api_key=x",
+			secrets: []string{"x"},
+			want:    "GET https://api.spacexdata.com/v4/fixture?api_key=REDACTED",
+		},
+		{
+			name: "authorization header",
+			in:   `header map[Authorization:[Token 0123abcd]] "authorization": "Bearer eyJhbGciOi"`,
+			want: `header map[Authorization:[Token REDACTED]] "authorization": "Bearer REDACTED"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Redact(tt.in, tt.secrets...))
+		})
+	}
+}
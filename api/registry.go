@@ -0,0 +1,92 @@
+package api
+
+import (
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"sync"
+
+	"github.com/MitiaRD/ReMarkable-cli/model"
+)
+
+// ProviderOptions is everything a ProviderFactory gets to build a provider.
+type ProviderOptions struct {
+	// Name is the provider's name in the config, which its Name method
+	// should return.
+	Name     string
+	Settings model.ProviderConfig
+	Config   *model.Config
+	Logger   *slog.Logger
+	// RequesterOptions carry the CLI's cache, circuit breakers and record or
+	// replay transport. HTTP based providers pass them to NewRequester.
+	RequesterOptions []RequesterOption
+}
+
+// ProviderFactory builds a LaunchProvider from its configuration.
+type ProviderFactory func(options ProviderOptions) (LaunchProvider, error)
+
+var registry = struct {
+	sync.RWMutex
+	factories map[string]ProviderFactory
+}{factories: make(map[string]ProviderFactory)}
+
+// RegisterProvider makes a provider type available to the providers section
+// of the config. It is meant to be called from the init function of the
+// package implementing the provider, which the CLI then imports for its side
+// effects. Registering a type twice, or under a built-in provider's name,
+// panics.
+func RegisterProvider(providerType string, factory ProviderFactory) {
+	registry.Lock()
+	defer registry.Unlock()
+
+	if factory == nil {
+		panic("api: RegisterProvider factory is nil")
+	}
+	switch providerType {
+	case "", model.ProviderSpaceX, model.ProviderLaunchLibrary, model.ProviderAll:
+		panic(fmt.Sprintf("api: provider type %q is reserved", providerType))
+	}
+	if _, dup := registry.factories[providerType]; dup {
+		panic(fmt.Sprintf("api: RegisterProvider called twice for %q", providerType))
+	}
+	registry.factories[providerType] = factory
+}
+
+// RegisteredProviders lists the registered provider types.
+func RegisteredProviders() []string {
+	registry.RLock()
+	defer registry.RUnlock()
+	return slices.Sorted(maps.Keys(registry.factories))
+}
+
+// ProviderType is the registered type that builds the provider called name.
+func ProviderType(name string, settings model.ProviderConfig) string {
+	switch {
+	case settings.Type != "":
+		return settings.Type
+	case settings.Command != "":
+		return execProviderType
+	default:
+		return name
+	}
+}
+
+// NewProvider builds the provider described by options with the factory
+// registered for its type.
+func NewProvider(options ProviderOptions) (LaunchProvider, error) {
+	providerType := ProviderType(options.Name, options.Settings)
+
+	registry.RLock()
+	factory, ok := registry.factories[providerType]
+	registry.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown launch provider type %q", providerType)
+	}
+
+	provider, err := factory(options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create launch provider %s: %w", options.Name, err)
+	}
+	return provider, nil
+}
@@ -0,0 +1,73 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/MitiaRD/ReMarkable-cli/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// staticProvider serves a fixed list of launches.
+type staticProvider struct {
+	name     string
+	launches []model.Launch
+}
+
+func (p staticProvider) Name() string { return p.name }
+
+func (p staticProvider) QueryLaunches(ctx context.Context, query *LaunchQuery) (Page[model.Launch], error) {
+	return Paginate(p.launches, query.Options()), nil
+}
+
+func (p staticProvider) GetRockets(ctx context.Context) (map[string]model.Rocket, error) {
+	return map[string]model.Rocket{}, nil
+}
+
+func (p staticProvider) GetLaunchpads(ctx context.Context) (map[string]model.Launchpad, error) {
+	return map[string]model.Launchpad{}, nil
+}
+
+// registerStatic registers staticProvider once per test binary, as a second
+// registration panics.
+var registerStatic = sync.OnceFunc(func() {
+	RegisterProvider("static-test", func(options ProviderOptions) (LaunchProvider, error) {
+		if options.Settings.Settings["broken"] == true {
+			return nil, errors.New("broken settings")
+		}
+		return staticProvider{name: options.Name}, nil
+	})
+})
+
+func TestRegisterProvider(t *testing.T) {
+	registerStatic()
+	assert.Contains(t, RegisteredProviders(), "static-test")
+	assert.Contains(t, RegisteredProviders(), "exec")
+
+	provider, err := NewProvider(ProviderOptions{Name: "manifest", Settings: model.ProviderConfig{Type: "static-test"}})
+	require.NoError(t, err)
+	assert.Equal(t, "manifest", provider.Name())
+
+	_, err = NewProvider(ProviderOptions{Name: "manifest", Settings: model.ProviderConfig{Type: "static-test", Settings: map[string]any{"broken": true}}})
+	assert.ErrorContains(t, err, "failed to create launch provider manifest: broken settings")
+
+	_, err = NewProvider(ProviderOptions{Name: "manifest"})
+	assert.ErrorContains(t, err, `unknown launch provider type "manifest"`)
+
+	assert.Panics(t, func() {
+		RegisterProvider("static-test", func(ProviderOptions) (LaunchProvider, error) { return nil, nil })
+	})
+	assert.Panics(t, func() {
+		RegisterProvider(model.ProviderSpaceX, func(ProviderOptions) (LaunchProvider, error) { return nil, nil })
+	})
+	assert.Panics(t, func() { RegisterProvider("static-nil", nil) })
+}
+
+func TestProviderType(t *testing.T) {
+	assert.Equal(t, "manifest", ProviderType("manifest", model.ProviderConfig{}))
+	assert.Equal(t, "exec", ProviderType("manifest", model.ProviderConfig{Command: "manifest-provider"}))
+	assert.Equal(t, "custom", ProviderType("manifest", model.ProviderConfig{Type: "custom", Command: "manifest-provider"}))
+}
@@ -0,0 +1,510 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/MitiaRD/ReMarkable-cli/model"
+)
+
+// Request describes a single API call executed by a Requester.
+type Request struct {
+	Method string
+	URL    string
+	Header http.Header
+	Body   []byte
+
+	// Decoder overrides the requester's decoder for this call.
+	Decoder Decoder
+	// Cache selects the TTL class for the response. Empty disables caching.
+	Cache CacheClass
+}
+
+// RetryPolicy decides whether a failed attempt is worth repeating.
+type RetryPolicy interface {
+	// ShouldRetry is called with the zero-based attempt number and either the
+	// non-2xx response or the error that ended the attempt.
+	ShouldRetry(attempt int, resp *http.Response, err error) bool
+}
+
+// BackoffStrategy returns how long to wait before the next attempt, given
+// the zero-based attempt number and the previous delay (zero at first).
+type BackoffStrategy interface {
+	Delay(attempt int, previous time.Duration) time.Duration
+}
+
+// Decoder turns a response body into a Go value.
+type Decoder interface {
+	Decode(body []byte, v any) error
+}
+
+// MaxRetriesPolicy retries transport errors, 429 and 5xx responses up to
+// MaxRetries times.
+type MaxRetriesPolicy struct {
+	MaxRetries int
+}
+
+func (p MaxRetriesPolicy) ShouldRetry(attempt int, resp *http.Response, err error) bool {
+	if attempt >= p.MaxRetries {
+		return false
+	}
+	if err != nil {
+		return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	}
+	return resp.StatusCode == http.StatusTooManyRequests || (resp.StatusCode >= 500 && resp.StatusCode < 600)
+}
+
+// JSONDecoder decodes response bodies with encoding/json.
+type JSONDecoder struct{}
+
+func (JSONDecoder) Decode(body []byte, v any) error {
+	return json.Unmarshal(body, v)
+}
+
+// StatusError is returned when an API answers with a non-2xx status.
+type StatusError struct {
+	StatusCode int
+	Body       string
+	Attempts   int
+}
+
+func (e *StatusError) Error() string {
+	if e.Attempts > 1 {
+		return fmt.Sprintf("API returned status %d after %d attempts: %s", e.StatusCode, e.Attempts, e.Body)
+	}
+	return fmt.Sprintf("API returned status %d: %s", e.StatusCode, e.Body)
+}
+
+// Requester is the shared HTTP executor behind every API client. It owns the
+// retry loop so that all providers behave the same way on failure.
+type Requester struct {
+	httpClient  *http.Client
+	logger      *slog.Logger
+	retryPolicy RetryPolicy
+	backoff     BackoffStrategy
+	decoder     Decoder
+	cache       *Cache
+	refresh     bool
+	limiter     *RateLimiter
+	breakers    *Breakers
+	maxDelay    time.Duration
+	auth        Authenticator
+	secrets     []string
+}
+
+type RequesterOption func(*Requester)
+
+func WithHTTPClient(httpClient *http.Client) RequesterOption {
+	return func(r *Requester) {
+		r.httpClient = httpClient
+	}
+}
+
+// WithTransport swaps the RoundTripper used by the requester's HTTP client,
+// e.g. for recording or replaying traffic.
+func WithTransport(transport http.RoundTripper) RequesterOption {
+	return func(r *Requester) {
+		client := *r.httpClient
+		client.Transport = transport
+		r.httpClient = &client
+	}
+}
+
+func WithRetryPolicy(policy RetryPolicy) RequesterOption {
+	return func(r *Requester) {
+		r.retryPolicy = policy
+	}
+}
+
+func WithBackoff(backoff BackoffStrategy) RequesterOption {
+	return func(r *Requester) {
+		r.backoff = backoff
+	}
+}
+
+func WithDecoder(decoder Decoder) RequesterOption {
+	return func(r *Requester) {
+		r.decoder = decoder
+	}
+}
+
+// WithCache stores cacheable responses in cache. With refresh set, stored
+// entries are never served but are still overwritten by fresh responses.
+func WithCache(cache *Cache, refresh bool) RequesterOption {
+	return func(r *Requester) {
+		r.cache = cache
+		r.refresh = refresh
+	}
+}
+
+// WithRateLimiter throttles network requests through limiter. Cache hits
+// are not counted.
+func WithRateLimiter(limiter *RateLimiter) RequesterOption {
+	return func(r *Requester) {
+		r.limiter = limiter
+	}
+}
+
+// WithBreakers shares a per-host circuit breaker registry between requesters.
+func WithBreakers(breakers *Breakers) RequesterOption {
+	return func(r *Requester) {
+		r.breakers = breakers
+	}
+}
+
+// WithAuth attaches credentials to every request sent over the network and
+// masks them in errors and stored responses.
+func WithAuth(auth Authenticator) RequesterOption {
+	return func(r *Requester) {
+		r.auth = auth
+		r.secrets = auth.Secrets()
+	}
+}
+
+func NewRequester(config *model.Config, logger *slog.Logger, opts ...RequesterOption) *Requester {
+	r := &Requester{
+		httpClient: &http.Client{
+			Timeout: config.Timeout,
+		},
+		logger:      logger,
+		retryPolicy: MaxRetriesPolicy{MaxRetries: config.Retries},
+		backoff:     NewBackoff(config.Backoff, config.BaseDelay, config.MaxDelay),
+		decoder:     JSONDecoder{},
+		breakers:    NewBreakers(config.BreakerThreshold, config.BreakerCooldown),
+		maxDelay:    config.MaxDelay,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// Do executes req and decodes the successful response body into out.
+func (r *Requester) Do(ctx context.Context, req Request, out any) error {
+	decoder := req.Decoder
+	if decoder == nil {
+		decoder = r.decoder
+	}
+	if req.Method == "" {
+		req.Method = http.MethodGet
+	}
+
+	var cacheKey string
+	var stale *CacheEntry
+	if r.cache != nil && req.Cache != "" {
+		cacheKey = CacheKey(req.Method, req.URL, req.Body)
+		entry, err := r.cache.Get(cacheKey)
+		// Refreshing bypasses the cache entirely: no cached body and no
+		// conditional request that could bring one back.
+		if err == nil && !r.refresh {
+			if r.cache.Fresh(entry) && decoder.Decode(entry.Body, out) == nil {
+				r.logger.Debug("cache hit", "url", req.URL)
+				return nil
+			}
+			if entry.Revalidatable() {
+				stale = entry
+				req.Header = withValidators(req.Header, entry)
+			}
+		}
+	}
+
+	var delay time.Duration
+	for attempt := 0; ; attempt++ {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		httpReq, err := newHTTPRequest(ctx, req)
+		if err != nil {
+			return fmt.Errorf("failed to create request: %w", redactError(err, r.secrets...))
+		}
+		if r.auth != nil {
+			r.auth.Authenticate(httpReq)
+		}
+
+		if r.limiter != nil {
+			if err := r.limiter.Wait(ctx); err != nil {
+				return err
+			}
+		}
+
+		breaker := r.breakers.For(httpReq.URL.Host)
+		if err := breaker.Allow(); err != nil {
+			return err
+		}
+
+		resp, err := r.httpClient.Do(httpReq)
+		if r.limiter != nil && resp != nil {
+			r.limiter.Observe(resp.Header)
+		}
+		if err != nil {
+			var missing *FixtureMissingError
+			if errors.As(err, &missing) {
+				breaker.Release()
+				return missing
+			}
+			err = redactError(err, r.secrets...)
+			if ctx.Err() != nil {
+				// Our own cancellation says nothing about the upstream.
+				breaker.Release()
+			} else {
+				breaker.Failure()
+			}
+			failure := fmt.Errorf("HTTP request failed after %d attempts: %w", attempt+1, err)
+			if !r.retryPolicy.ShouldRetry(attempt, nil, err) {
+				return failure
+			}
+			delay = r.backoff.Delay(attempt, delay)
+			if err := r.wait(ctx, attempt, delay, failure, "HTTP request failed, retrying", "error", err); err != nil {
+				return err
+			}
+			continue
+		}
+
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+
+		if resp.StatusCode >= 500 {
+			breaker.Failure()
+		} else {
+			breaker.Success()
+		}
+
+		if resp.StatusCode == http.StatusNotModified && stale != nil {
+			if err := decoder.Decode(stale.Body, out); err == nil {
+				r.logger.Debug("cache revalidated", "url", req.URL)
+				// A 304 may carry updated validators for the same body.
+				if etag := resp.Header.Get("ETag"); etag != "" {
+					stale.ETag = etag
+				}
+				if lastModified := resp.Header.Get("Last-Modified"); lastModified != "" {
+					stale.LastModified = lastModified
+				}
+				stale.StoredAt = time.Time{}
+				r.storeEntry(stale)
+				return nil
+			}
+			// The stored body no longer decodes; fetch it again in full.
+			req.Header = withoutValidators(req.Header)
+			stale = nil
+			continue
+		}
+
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			failure := &StatusError{StatusCode: resp.StatusCode, Body: Redact(string(body), r.secrets...), Attempts: attempt + 1}
+			if !r.retryPolicy.ShouldRetry(attempt, resp, nil) {
+				return failure
+			}
+			delay = r.retryDelay(resp, attempt, delay)
+			if err := r.wait(ctx, attempt, delay, failure, "rate limited or server error, retrying", "status", resp.StatusCode); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err != nil {
+			failure := fmt.Errorf("failed to read response body after %d attempts: %w", attempt+1, redactError(err, r.secrets...))
+			if !r.retryPolicy.ShouldRetry(attempt, nil, err) {
+				return failure
+			}
+			delay = r.backoff.Delay(attempt, delay)
+			if err := r.wait(ctx, attempt, delay, failure, "failed to read response, retrying", "error", err); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := decoder.Decode(body, out); err != nil {
+			failure := fmt.Errorf("failed to parse JSON after %d attempts: %w. Response: %s", attempt+1, err, Redact(string(body[:min(200, len(body))]), r.secrets...))
+			if !r.retryPolicy.ShouldRetry(attempt, nil, err) {
+				return failure
+			}
+			delay = r.backoff.Delay(attempt, delay)
+			if err := r.wait(ctx, attempt, delay, failure, "failed to parse JSON, retrying", "error", err); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if cacheKey != "" {
+			r.storeEntry(&CacheEntry{
+				Key:          cacheKey,
+				Class:        req.Cache,
+				Method:       req.Method,
+				URL:          req.URL,
+				Body:         []byte(Redact(string(body), r.secrets...)),
+				ETag:         resp.Header.Get("ETag"),
+				LastModified: resp.Header.Get("Last-Modified"),
+			})
+		}
+		return nil
+	}
+}
+
+// Stream GETs url and copies the response body to w, failing once more than
+// limit bytes have arrived. It is meant for binary downloads, so the body
+// skips the cache and redaction. As part of it may already be in w when
+// something goes wrong, the request is never retried.
+func (r *Requester) Stream(ctx context.Context, url string, w io.Writer, limit int64) (int64, error) {
+	httpReq, err := newHTTPRequest(ctx, Request{Method: http.MethodGet, URL: url})
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", redactError(err, r.secrets...))
+	}
+	if r.auth != nil {
+		r.auth.Authenticate(httpReq)
+	}
+
+	if r.limiter != nil {
+		if err := r.limiter.Wait(ctx); err != nil {
+			return 0, err
+		}
+	}
+
+	breaker := r.breakers.For(httpReq.URL.Host)
+	if err := breaker.Allow(); err != nil {
+		return 0, err
+	}
+
+	resp, err := r.httpClient.Do(httpReq)
+	if r.limiter != nil && resp != nil {
+		r.limiter.Observe(resp.Header)
+	}
+	if err != nil {
+		var missing *FixtureMissingError
+		if errors.As(err, &missing) {
+			breaker.Release()
+			return 0, missing
+		}
+		if ctx.Err() != nil {
+			breaker.Release()
+		} else {
+			breaker.Failure()
+		}
+		return 0, fmt.Errorf("HTTP request failed: %w", redactError(err, r.secrets...))
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 500 {
+		breaker.Failure()
+	} else {
+		breaker.Success()
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 200))
+		return 0, &StatusError{StatusCode: resp.StatusCode, Body: Redact(string(body), r.secrets...), Attempts: 1}
+	}
+	if resp.ContentLength > limit {
+		return 0, fmt.Errorf("response body of %d bytes exceeds the %d byte limit", resp.ContentLength, limit)
+	}
+
+	// Reading one byte past the limit tells a body of exactly limit bytes
+	// from a longer one.
+	n, err := io.Copy(w, io.LimitReader(resp.Body, limit+1))
+	if err != nil {
+		return n, fmt.Errorf("failed to read response body: %w", redactError(err, r.secrets...))
+	}
+	if n > limit {
+		return n, fmt.Errorf("response body exceeds the %d byte limit", limit)
+	}
+	return n, nil
+}
+
+func (r *Requester) storeEntry(entry *CacheEntry) {
+	if err := r.cache.Put(entry); err != nil {
+		r.logger.Warn("failed to write cache entry", "url", entry.URL, "error", err)
+	}
+}
+
+func withValidators(header http.Header, entry *CacheEntry) http.Header {
+	header = header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	if entry.ETag != "" {
+		header.Set("If-None-Match", entry.ETag)
+	}
+	if entry.LastModified != "" {
+		header.Set("If-Modified-Since", entry.LastModified)
+	}
+	return header
+}
+
+func withoutValidators(header http.Header) http.Header {
+	header = header.Clone()
+	header.Del("If-None-Match")
+	header.Del("If-Modified-Since")
+	return header
+}
+
+// retryDelay honours a Retry-After header, capped at the configured maximum
+// delay, and falls back to the backoff strategy without one.
+func (r *Requester) retryDelay(resp *http.Response, attempt int, previous time.Duration) time.Duration {
+	if delay, ok := ParseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+		return min(delay, r.maxDelay)
+	}
+	return r.backoff.Delay(attempt, previous)
+}
+
+// wait sleeps before the next attempt. When the delay would outlast the
+// context deadline it gives up straight away with failure, and it stops
+// early if the context is cancelled.
+func (r *Requester) wait(ctx context.Context, attempt int, delay time.Duration, failure error, msg string, args ...any) error {
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+		return fmt.Errorf("not retrying, next attempt in %s would pass the deadline: %w", delay, failure)
+	}
+	r.logger.Warn(msg, append([]any{"attempt", attempt + 1, "delay", delay}, args...)...)
+	return sleep(ctx, delay)
+}
+
+func newHTTPRequest(ctx context.Context, req Request) (*http.Request, error) {
+	var body io.Reader
+	if req.Body != nil {
+		body = bytes.NewReader(req.Body)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, req.Method, req.URL, body)
+	if err != nil {
+		return nil, err
+	}
+	for key, values := range req.Header {
+		for _, value := range values {
+			httpReq.Header.Add(key, value)
+		}
+	}
+	return httpReq, nil
+}
+
+func fetch[T any](ctx context.Context, r *Requester, req Request) (T, error) {
+	var result T
+	err := r.Do(ctx, req, &result)
+	return result, err
+}
+
+func getJSON[T any](ctx context.Context, r *Requester, url string, class CacheClass) (T, error) {
+	return fetch[T](ctx, r, Request{Method: http.MethodGet, URL: url, Cache: class})
+}
+
+func postJSON[T any](ctx context.Context, r *Requester, url string, payload any, class CacheClass) (T, error) {
+	var result T
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return result, fmt.Errorf("failed to marshal query: %w", err)
+	}
+	return fetch[T](ctx, r, Request{
+		Method: http.MethodPost,
+		URL:    url,
+		Header: http.Header{"Content-Type": []string{"application/json"}},
+		Body:   body,
+		Cache:  class,
+	})
+}
@@ -0,0 +1,161 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/MitiaRD/ReMarkable-cli/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testConfig() *model.Config {
+	config := model.DefaultConfig()
+	config.Retries = 2
+	config.BaseDelay = time.Millisecond
+	config.MaxDelay = time.Millisecond
+	return config
+}
+
+func testLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+func TestRequesterDo(t *testing.T) {
+	tests := []struct {
+		name         string
+		statuses     []int
+		expectErr    bool
+		expectStatus int
+		expectCalls  int
+	}{
+		{
+			name:        "success on first attempt",
+			statuses:    []int{http.StatusOK},
+			expectCalls: 1,
+		},
+		{
+			name:        "retries server errors",
+			statuses:    []int{http.StatusInternalServerError, http.StatusTooManyRequests, http.StatusOK},
+			expectCalls: 3,
+		},
+		{
+			name:         "does not retry client errors",
+			statuses:     []int{http.StatusNotFound},
+			expectErr:    true,
+			expectStatus: http.StatusNotFound,
+			expectCalls:  1,
+		},
+		{
+			name:         "gives up after max retries",
+			statuses:     []int{http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway, http.StatusOK},
+			expectErr:    true,
+			expectStatus: http.StatusBadGateway,
+			expectCalls:  3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				status := tt.statuses[calls]
+				calls++
+				w.WriteHeader(status)
+				if status == http.StatusOK {
+					io.WriteString(w, `{"name":"Falcon 9"}`)
+				}
+			}))
+			defer server.Close()
+
+			requester := NewRequester(testConfig(), testLogger())
+			result, err := getJSON[model.Rocket](context.Background(), requester, server.URL, "")
+
+			assert.Equal(t, tt.expectCalls, calls)
+			if tt.expectErr {
+				var statusErr *StatusError
+				require.ErrorAs(t, err, &statusErr)
+				assert.Equal(t, tt.expectStatus, statusErr.StatusCode)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "Falcon 9", result.Name)
+		})
+	}
+}
+
+func TestRequesterPostsJSON(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		body, _ := io.ReadAll(r.Body)
+		assert.JSONEq(t, `{"query":{"upcoming":true}}`, string(body))
+		io.WriteString(w, `{"docs":[{"name":"Crew-9"}]}`)
+	}))
+	defer server.Close()
+
+	requester := NewRequester(testConfig(), testLogger())
+	result, err := postJSON[struct {
+		Docs []model.Launch `json:"docs"`
+	}](context.Background(), requester, server.URL, map[string]interface{}{
+		"query": map[string]interface{}{"upcoming": true},
+	}, "")
+
+	require.NoError(t, err)
+	require.Len(t, result.Docs, 1)
+	assert.Equal(t, "Crew-9", result.Docs[0].Name)
+}
+
+func TestRequesterStream(t *testing.T) {
+	tests := []struct {
+		name      string
+		path      string
+		expectErr string
+		expect    string
+	}{
+		{name: "within the limit", path: "/small", expect: "0123456789"},
+		{name: "declared too large", path: "/large", expectErr: "response body of 11 bytes exceeds the 10 byte limit"},
+		{name: "streamed too large", path: "/chunked", expectErr: "response body exceeds the 10 byte limit"},
+		{name: "server error", path: "/unavailable", expectErr: "API returned status 503"},
+	}
+
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		switch r.URL.Path {
+		case "/small":
+			io.WriteString(w, "0123456789")
+		case "/large":
+			io.WriteString(w, "0123456789a")
+		case "/chunked":
+			io.WriteString(w, "01234")
+			w.(http.Flusher).Flush()
+			io.WriteString(w, "56789a")
+		default:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	requester := NewRequester(testConfig(), testLogger())
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls = 0
+			var out bytes.Buffer
+			_, err := requester.Stream(context.Background(), server.URL+tt.path, &out, 10)
+			assert.Equal(t, 1, calls, "streams are never retried")
+			if tt.expectErr != "" {
+				assert.ErrorContains(t, err, tt.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expect, out.String())
+		})
+	}
+}
@@ -0,0 +1,229 @@
+package api
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// Fixture is one recorded request and the response it received.
+type Fixture struct {
+	Request  FixtureRequest  `json:"request"`
+	Response FixtureResponse `json:"response"`
+}
+
+type FixtureRequest struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	Body   string `json:"body,omitempty"`
+}
+
+type FixtureResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header"`
+	Body       string      `json:"body"`
+	// Encoding is "base64" for binary bodies, such as media files, and
+	// empty for text.
+	Encoding string `json:"encoding,omitempty"`
+}
+
+const base64Encoding = "base64"
+
+// newFixtureResponse stores body as text, with secrets masked, or base64
+// encoded when it is not valid UTF-8.
+func newFixtureResponse(resp *http.Response, body []byte, secrets []string) FixtureResponse {
+	fixture := FixtureResponse{StatusCode: resp.StatusCode, Header: resp.Header.Clone()}
+	if utf8.Valid(body) {
+		// NeoWs echoes the api_key in its pagination links.
+		fixture.Body = Redact(string(body), secrets...)
+	} else {
+		fixture.Body = base64.StdEncoding.EncodeToString(body)
+		fixture.Encoding = base64Encoding
+	}
+	return fixture
+}
+
+func (r FixtureResponse) body() ([]byte, error) {
+	switch r.Encoding {
+	case "":
+		return []byte(r.Body), nil
+	case base64Encoding:
+		return base64.StdEncoding.DecodeString(r.Body)
+	default:
+		return nil, fmt.Errorf("unknown body encoding %q", r.Encoding)
+	}
+}
+
+// RecordingTransport forwards requests to Next and saves every exchange as a
+// fixture in Dir. Credential parameters and Secrets are masked in the saved
+// text bodies. Bodies larger than MaxMediaBytes are passed on unrecorded.
+type RecordingTransport struct {
+	Dir     string
+	Next    http.RoundTripper
+	Secrets []string
+}
+
+func NewRecordingTransport(dir string, next http.RoundTripper, secrets ...string) (*RecordingTransport, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create fixture directory: %w", err)
+	}
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &RecordingTransport{Dir: dir, Next: next, Secrets: secrets}, nil
+}
+
+func (t *RecordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := t.Next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, MaxMediaBytes+1))
+	if err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	if int64(len(respBody)) > MaxMediaBytes {
+		// Hand the caller the whole body so it can apply its own limit.
+		resp.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(respBody), resp.Body), resp.Body}
+		return resp, nil
+	}
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	fixture := Fixture{
+		Request: FixtureRequest{
+			Method: req.Method,
+			URL:    redactURL(req.URL.String()),
+			Body:   string(reqBody),
+		},
+		Response: newFixtureResponse(resp, respBody, t.Secrets),
+	}
+	data, err := json.MarshalIndent(fixture, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	path := filepath.Join(t.Dir, fixtureName(fixture.Request))
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return nil, fmt.Errorf("failed to write fixture: %w", err)
+	}
+	return resp, nil
+}
+
+// FixtureMissingError reports a replayed request without a recorded
+// fixture. Requesters return it straight away: retrying cannot make the
+// fixture appear, and it says nothing about the upstream's health.
+type FixtureMissingError struct {
+	Method string
+	URL    string
+	// Path is the fixture file the request would have been served from.
+	Path string
+}
+
+func (e *FixtureMissingError) Error() string {
+	return fmt.Sprintf("no recorded fixture for %s %s (expected %s)", e.Method, e.URL, e.Path)
+}
+
+// ReplayTransport serves responses from fixtures in Dir and never touches
+// the network. Requests without a fixture fail.
+type ReplayTransport struct {
+	Dir string
+}
+
+func NewReplayTransport(dir string) (*ReplayTransport, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open fixture directory: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("fixture path %s is not a directory", dir)
+	}
+	return &ReplayTransport{Dir: dir}, nil
+}
+
+func (t *ReplayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	key := FixtureRequest{Method: req.Method, URL: redactURL(req.URL.String()), Body: string(reqBody)}
+	path := filepath.Join(t.Dir, fixtureName(key))
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, &FixtureMissingError{Method: key.Method, URL: key.URL, Path: path}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fixture %s: %w", path, err)
+	}
+
+	var fixture Fixture
+	if err := json.Unmarshal(data, &fixture); err != nil {
+		return nil, fmt.Errorf("corrupt fixture for %s %s: %w", key.Method, key.URL, err)
+	}
+	body, err := fixture.Response.body()
+	if err != nil {
+		return nil, fmt.Errorf("corrupt fixture for %s %s: %w", key.Method, key.URL, err)
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", fixture.Response.StatusCode, http.StatusText(fixture.Response.StatusCode)),
+		StatusCode:    fixture.Response.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        fixture.Response.Header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+var unsafeFixtureChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// fixtureName derives a stable, readable file name from the scrubbed request.
+func fixtureName(req FixtureRequest) string {
+	slug := req.URL
+	if u, err := url.Parse(req.URL); err == nil {
+		slug = u.Host + u.Path
+	}
+	slug = strings.Trim(unsafeFixtureChars.ReplaceAllString(slug, "_"), "_")
+	if len(slug) > 80 {
+		slug = slug[:80]
+	}
+	hash := CacheKey(req.Method, req.URL, []byte(req.Body))
+	return fmt.Sprintf("%s_%s_%s.json", req.Method, slug, hash[:12])
+}
+
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil {
+		return nil, nil
+	}
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request body: %w", err)
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
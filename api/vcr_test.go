@@ -0,0 +1,121 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	dir := t.TempDir()
+	jpeg := []byte{0xff, 0xd8, 0xff, 0xe0, 0x00}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/neo/rest/v1/feed":
+			io.WriteString(w, `{"element_count":7}`)
+		case "/launches/query":
+			io.WriteString(w, `{"docs":[{"name":"Crew-9"}],"totalDocs":1}`)
+		case "/image/apod.jpg":
+			w.Write(jpeg)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+
+	config := testConfig()
+	config.NASAAPIKey = "secret-key"
+	config.NASABaseURL = server.URL
+	config.SpaceXBaseURL = server.URL
+
+	recorder, err := NewRecordingTransport(dir, nil)
+	require.NoError(t, err)
+	nasa := NewNASAClient(config, testLogger(), WithTransport(recorder))
+	spaceX := NewSpaceXClient(config, testLogger(), WithTransport(recorder))
+
+	asteroids, err := nasa.GetAsteroids(context.Background(), "?start_date=2024-01-01&end_date=2024-01-01")
+	require.NoError(t, err)
+	assert.Equal(t, 7, asteroids.ElementCount)
+	launches, err := spaceX.GetLaunchesWithQuery(context.Background(), NewLaunchQuery().Limit(1))
+	require.NoError(t, err)
+	require.Len(t, launches, 1)
+	var media bytes.Buffer
+	_, err = nasa.DownloadMedia(context.Background(), server.URL+"/image/apod.jpg", &media)
+	require.NoError(t, err)
+	assert.Equal(t, jpeg, media.Bytes())
+
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, files, 3)
+	for _, file := range files {
+		data, err := os.ReadFile(filepath.Join(dir, file.Name()))
+		require.NoError(t, err)
+		assert.NotContains(t, string(data), "secret-key")
+	}
+
+	// With the server gone, only the fixtures can answer.
+	server.Close()
+
+	replay, err := NewReplayTransport(dir)
+	require.NoError(t, err)
+	config.NASAAPIKey = "another-key"
+	nasa = NewNASAClient(config, testLogger(), WithTransport(replay))
+	spaceX = NewSpaceXClient(config, testLogger(), WithTransport(replay))
+
+	asteroids, err = nasa.GetAsteroids(context.Background(), "?start_date=2024-01-01&end_date=2024-01-01")
+	require.NoError(t, err)
+	assert.Equal(t, 7, asteroids.ElementCount)
+	launches, err = spaceX.GetLaunchesWithQuery(context.Background(), NewLaunchQuery().Limit(1))
+	require.NoError(t, err)
+	assert.Equal(t, "Crew-9", launches[0].Name)
+	media.Reset()
+	_, err = nasa.DownloadMedia(context.Background(), server.URL+"/image/apod.jpg", &media)
+	require.NoError(t, err)
+	assert.Equal(t, jpeg, media.Bytes(), "binary bodies survive the round trip")
+
+	// A miss names the fixture and is neither retried nor counted against
+	// the host's circuit breaker.
+	_, err = spaceX.GetAllRockets(context.Background())
+	var missing *FixtureMissingError
+	require.ErrorAs(t, err, &missing)
+	assert.Equal(t, dir, filepath.Dir(missing.Path))
+	assert.ErrorContains(t, err, "no recorded fixture")
+	for range config.BreakerThreshold {
+		_, err = spaceX.GetAllRockets(context.Background())
+		require.ErrorAs(t, err, &missing)
+	}
+	_, err = spaceX.GetLaunchesWithQuery(context.Background(), NewLaunchQuery().Limit(1))
+	assert.NoError(t, err)
+}
+
+func TestRecordingMasksSecrets(t *testing.T) {
+	const token = "ll2-token-0123456789"
+	dir := t.TempDir()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"count":0,"results":[],"echo":"`+r.Header.Get("Authorization")+`"}`)
+	}))
+	defer server.Close()
+
+	config := testConfig()
+	config.LL2BaseURL = server.URL
+	config.LL2APIToken = token
+	recorder, err := NewRecordingTransport(dir, nil, token)
+	require.NoError(t, err)
+
+	_, err = NewLaunchLibraryClient(config, testLogger(), WithTransport(recorder)).QueryAgencies(context.Background(), nil)
+	require.NoError(t, err)
+
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	data, err := os.ReadFile(filepath.Join(dir, files[0].Name()))
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), token)
+}
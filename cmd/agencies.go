@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/MitiaRD/ReMarkable-cli/api"
+	"github.com/MitiaRD/ReMarkable-cli/model"
+	"github.com/spf13/cobra"
+)
+
+var agenciesCmd = &cobra.Command{
+	Use:   "agencies",
+	Short: "List space agencies and launch service providers",
+	Long: `Agencies lists the space agencies and launch service providers known to
+Launch Library 2.
+
+Available subcommands:
+  search       - Match agencies whose name or abbreviation contains this text,
+  country      - Filter by country code, e.g. USA or CHN,
+  type         - Filter by type, e.g. Government or Commercial,
+  limit        - Number of agencies to show,
+  page         - Page of results to show`,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		config, err := LoadConfiguration()
+		if err != nil {
+			fmt.Printf("Error loading configuration: %v\n", err)
+			return
+		}
+
+		logger := SetupLogger()
+		service, err := NewLaunchesService(config, logger)
+		if err != nil {
+			fmt.Printf("Error setting up API clients: %v\n", err)
+			return
+		}
+
+		page, err := service.QueryAgencies(ctx, buildAgencyQuery(cmd))
+		if err != nil {
+			logger.Error("failed to fetch agencies", "error", err)
+			fmt.Printf("Error fetching agencies: %v\n", err)
+			return
+		}
+
+		fmt.Printf("\n🏢 Agencies (showing %d):\n", len(page.Docs))
+		if page.HasNextPage {
+			fmt.Printf("   %d agencies match in total (page %d of %d); use --page %d to see more\n", page.TotalDocs, page.Page, page.TotalPages, page.Page+1)
+		}
+		fmt.Println(strings.Repeat("-", 80))
+		for _, agency := range page.Docs {
+			printAgency(agency)
+			fmt.Println()
+		}
+	},
+}
+
+func buildAgencyQuery(cmd *cobra.Command) *api.LibraryQuery {
+	query := api.NewLibraryQuery()
+	if search, _ := cmd.Flags().GetString("search"); search != "" {
+		query.Search(search)
+	}
+	if country, _ := cmd.Flags().GetString("country"); country != "" {
+		query.Where("country_code", strings.ToUpper(country))
+	}
+	if agencyType, _ := cmd.Flags().GetString("type"); agencyType != "" {
+		query.Where("type", agencyType)
+	}
+	if limit, _ := cmd.Flags().GetInt("limit"); limit > 0 {
+		query.Limit(limit)
+	}
+	if page, _ := cmd.Flags().GetInt("page"); page > 0 {
+		query.Page(page)
+	}
+	return query
+}
+
+func printAgency(agency model.Agency) {
+	name := agency.Name
+	if agency.Abbrev != "" {
+		name += " (" + agency.Abbrev + ")"
+	}
+	fmt.Printf("🏢 %s\n", name)
+	fmt.Printf("   %s, %s\n", orUnknown(agency.Type), orUnknown(agency.CountryCode))
+	if agency.FoundingYear != "" {
+		fmt.Printf("   Founded %s\n", agency.FoundingYear)
+	}
+	if agency.Administrator != "" {
+		fmt.Printf("   %s\n", agency.Administrator)
+	}
+	if agency.Launchers != "" {
+		fmt.Printf("   🚀 %s\n", agency.Launchers)
+	}
+	if agency.Description != "" {
+		fmt.Printf("   ℹ️ %s\n", agency.Description)
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(agenciesCmd)
+
+	agenciesCmd.Flags().StringP("search", "q", "", "Match agencies whose name or abbreviation contains this text")
+	agenciesCmd.Flags().String("country", "", "Filter by country code, e.g. USA")
+	agenciesCmd.Flags().String("type", "", "Filter by type, e.g. Government or Commercial")
+	agenciesCmd.Flags().IntP("limit", "l", 20, "Number of agencies to show (at most 100)")
+	agenciesCmd.Flags().Int("page", 0, "Page of results to show, starting at 1")
+}
@@ -0,0 +1,158 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/MitiaRD/ReMarkable-cli/api"
+	"github.com/MitiaRD/ReMarkable-cli/model"
+	"github.com/spf13/cobra"
+)
+
+var apodCmd = &cobra.Command{
+	Use:   "apod",
+	Short: "Show NASA's Astronomy Picture of the Day",
+	Long: `Apod shows the Astronomy Picture of the Day for today, a given date, a date
+range or a number of random days, and can download the pictures.
+
+Available subcommands:
+  date         - Date of the picture (YYYY-MM-DD),
+  start        - Start date of a range (YYYY-MM-DD),
+  end          - End date of a range (YYYY-MM-DD, default today),
+  count        - Number of randomly chosen pictures,
+  thumbs       - Include thumbnails for videos,
+  download     - Save the media to this directory,
+  hd           - Download high resolution images`,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+		defer cancel()
+
+		config, err := LoadConfiguration()
+		if err != nil {
+			fmt.Printf("Error loading configuration: %v\n", err)
+			return
+		}
+
+		logger := SetupLogger()
+		service, err := NewLaunchesService(config, logger)
+		if err != nil {
+			fmt.Printf("Error setting up API clients: %v\n", err)
+			return
+		}
+
+		query, err := buildAPODQuery(cmd)
+		if err != nil {
+			fmt.Printf("Error reading dates: %v\n", err)
+			return
+		}
+
+		apods, err := service.GetAPOD(ctx, query)
+		if err != nil {
+			logger.Error("failed to fetch astronomy pictures", "error", err)
+			fmt.Printf("Error fetching astronomy pictures: %v\n", err)
+			return
+		}
+
+		dir, _ := cmd.Flags().GetString("download")
+		hd, _ := cmd.Flags().GetBool("hd")
+		fmt.Printf("\n🔭 Astronomy Picture of the Day (showing %d):\n", len(apods))
+		fmt.Println(strings.Repeat("-", 80))
+		for _, apod := range apods {
+			printAPOD(apod)
+			if dir != "" {
+				saveAPOD(ctx, service, apod, dir, hd)
+			}
+			fmt.Println()
+		}
+	},
+}
+
+func buildAPODQuery(cmd *cobra.Command) (*api.APODQuery, error) {
+	thumbs, _ := cmd.Flags().GetBool("thumbs")
+	query := api.NewAPODQuery().Thumbs(thumbs)
+
+	if count, _ := cmd.Flags().GetInt("count"); count > 0 {
+		return query.Count(count), nil
+	}
+
+	parse := func(flag string) (time.Time, error) {
+		value, _ := cmd.Flags().GetString(flag)
+		if value == "" {
+			return time.Time{}, nil
+		}
+		date, err := time.Parse(time.DateOnly, value)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid %s date %q: %w", flag, value, err)
+		}
+		return date, nil
+	}
+
+	date, err := parse("date")
+	if err != nil {
+		return nil, err
+	}
+	start, err := parse("start")
+	if err != nil {
+		return nil, err
+	}
+	end, err := parse("end")
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case !start.IsZero():
+		if !end.IsZero() && end.Before(start) {
+			return nil, fmt.Errorf("end date %s is before start date %s", end.Format(time.DateOnly), start.Format(time.DateOnly))
+		}
+		query.Between(start, end)
+	case !end.IsZero():
+		return nil, fmt.Errorf("--end requires --start")
+	case !date.IsZero():
+		query.Date(date)
+	}
+	return query, nil
+}
+
+func printAPOD(apod model.APOD) {
+	fmt.Printf("📅 %s  %s\n", apod.Date, apod.Title)
+	if apod.Copyright != "" {
+		fmt.Printf("   © %s\n", strings.TrimSpace(apod.Copyright))
+	}
+	fmt.Printf("   🖼️  %s: %s\n", apod.MediaType, apod.URL)
+	if apod.ThumbnailURL != "" {
+		fmt.Printf("   🎞️  thumbnail: %s\n", apod.ThumbnailURL)
+	}
+	if apod.Explanation != "" {
+		fmt.Printf("   ℹ️ %s\n", apod.Explanation)
+	}
+}
+
+// saveAPOD downloads the media of apod into dir and reports the outcome.
+func saveAPOD(ctx context.Context, service *LaunchesService, apod model.APOD, dir string, hd bool) {
+	path, err := service.DownloadAPOD(ctx, apod, dir, hd)
+	if err != nil {
+		service.logger.Error("failed to download astronomy picture", "date", apod.Date, "error", err)
+		fmt.Printf("   💾 Download failed: %v\n", err)
+		return
+	}
+	fmt.Printf("   💾 Saved to %s\n", path)
+}
+
+func init() {
+	rootCmd.AddCommand(apodCmd)
+
+	apodCmd.Flags().StringP("date", "d", "", "Date of the picture (YYYY-MM-DD, default today)")
+	apodCmd.Flags().StringP("start", "s", "", "Start date of a range (YYYY-MM-DD)")
+	apodCmd.Flags().StringP("end", "e", "", "End date of a range (YYYY-MM-DD, default today)")
+	apodCmd.Flags().IntP("count", "c", 0, "Number of randomly chosen pictures")
+	apodCmd.Flags().Bool("thumbs", false, "Include thumbnail URLs for videos")
+	apodCmd.Flags().String("download", "", "Save the media of each picture to this directory")
+	apodCmd.Flags().Bool("hd", false, "Download high resolution images when available")
+
+	apodCmd.MarkFlagsMutuallyExclusive("date", "start")
+	apodCmd.MarkFlagsMutuallyExclusive("date", "count")
+	apodCmd.MarkFlagsMutuallyExclusive("start", "count")
+}
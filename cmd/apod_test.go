@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/MitiaRD/ReMarkable-cli/api/apitest"
+	"github.com/MitiaRD/ReMarkable-cli/model"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLaunchAPODDownload(t *testing.T) {
+	launchDate := time.Date(2024, 9, 26, 22, 30, 0, 0, time.UTC)
+	nasa := apitest.NewNASA().
+		WithAPOD(
+			model.APOD{Date: "2024-09-26", Title: "Helix", MediaType: model.MediaImage, URL: "https://apod.nasa.gov/apod/image/2409/helix.jpg", HDURL: "https://apod.nasa.gov/apod/image/2409/helix_big.png"},
+			model.APOD{Date: "2024-09-27", Title: "Video", MediaType: model.MediaVideo, URL: "https://www.youtube.com/embed/x"},
+		).
+		WithMedia("https://apod.nasa.gov/apod/image/2409/helix_big.png", []byte("png"))
+	service := NewLaunchesServiceWithClients(Clients{NASA: nasa}, model.DefaultConfig(), slog.New(slog.NewTextHandler(io.Discard, nil)))
+	ctx := context.Background()
+
+	apod, ok, err := service.GetLaunchAPOD(ctx, launchDate, false)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, "Helix", apod.Title)
+
+	dir := filepath.Join(t.TempDir(), "apod")
+	path, err := service.DownloadAPOD(ctx, apod, dir, true)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "2024-09-26.png"), path)
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "png", string(content))
+
+	missing := apod
+	missing.HDURL = "https://apod.nasa.gov/apod/image/2409/missing.png"
+	_, err = service.DownloadAPOD(ctx, missing, dir, true)
+	require.Error(t, err)
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1, "failed downloads leave no file behind")
+
+	video, ok, err := service.GetLaunchAPOD(ctx, launchDate.AddDate(0, 0, 1), false)
+	require.NoError(t, err)
+	require.True(t, ok)
+	_, err = service.DownloadAPOD(ctx, video, dir, false)
+	assert.EqualError(t, err, "no downloadable media for 2024-09-27 (video)")
+
+	_, ok, err = service.GetLaunchAPOD(ctx, time.Now().AddDate(0, 1, 0), false)
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestLatestAPODDate(t *testing.T) {
+	tests := []struct {
+		now  time.Time
+		want time.Time
+	}{
+		{now: time.Date(2024, 9, 27, 2, 0, 0, 0, time.UTC), want: time.Date(2024, 9, 26, 0, 0, 0, 0, time.UTC)},
+		{now: time.Date(2024, 9, 27, 5, 0, 0, 0, time.UTC), want: time.Date(2024, 9, 27, 0, 0, 0, 0, time.UTC)},
+		{now: time.Date(2024, 12, 27, 4, 30, 0, 0, time.UTC), want: time.Date(2024, 12, 26, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, latestAPODDate(tt.now), tt.now)
+	}
+}
+
+func TestBuildAPODQuery(t *testing.T) {
+	tests := []struct {
+		name     string
+		flags    map[string]string
+		expected string
+		err      string
+	}{
+		{name: "today", flags: map[string]string{}, expected: ""},
+		{name: "date", flags: map[string]string{"date": "2024-09-26"}, expected: "?date=2024-09-26"},
+		{name: "range", flags: map[string]string{"start": "2024-09-01", "end": "2024-09-03", "thumbs": "true"}, expected: "?end_date=2024-09-03&start_date=2024-09-01&thumbs=true"},
+		{name: "count", flags: map[string]string{"count": "5"}, expected: "?count=5"},
+		{name: "end without start", flags: map[string]string{"end": "2024-09-03"}, err: "--end requires --start"},
+		{name: "reversed range", flags: map[string]string{"start": "2024-09-03", "end": "2024-09-01"}, err: "end date 2024-09-01 is before start date 2024-09-03"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &cobra.Command{}
+			cmd.Flags().String("date", "", "")
+			cmd.Flags().String("start", "", "")
+			cmd.Flags().String("end", "", "")
+			cmd.Flags().Int("count", 0, "")
+			cmd.Flags().Bool("thumbs", false, "")
+			for name, value := range tt.flags {
+				require.NoError(t, cmd.Flags().Set(name, value))
+			}
+
+			query, err := buildAPODQuery(cmd)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, query.Encode())
+		})
+	}
+}
@@ -0,0 +1,312 @@
+package cmd
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/MitiaRD/ReMarkable-cli/api"
+	"github.com/MitiaRD/ReMarkable-cli/model"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// asteroidFeedMaxDays is the longest range the NeoWs feed serves at once.
+	asteroidFeedMaxDays = 7
+	// asteroidFeedConcurrency bounds the feed requests in flight.
+	asteroidFeedConcurrency = 4
+)
+
+var asteroidSortKeys = []string{"date", "miss-distance", "diameter", "velocity", "name"}
+
+var asteroidsCmd = &cobra.Command{
+	Use:   "asteroids",
+	Short: "Explore near-Earth asteroid approaches",
+	Long: `Asteroids lists close approaches to Earth from the NASA NeoWs feed over any
+date range. Ranges longer than 7 days are fetched in concurrent chunks. Use
+"asteroids show <id>" for a single asteroid's orbit and approach history.
+
+Available subcommands:
+  start             - Start date (YYYY-MM-DD),
+  end               - End date (YYYY-MM-DD),
+  hazardous         - Only show potentially hazardous asteroids,
+  min-diameter      - Minimum estimated diameter in meters,
+  max-miss-distance - Maximum miss distance in kilometers,
+  sort              - Sort by date, miss-distance, diameter, velocity or name,
+  limit             - Number of approaches to show`,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+		defer cancel()
+
+		config, err := LoadConfiguration()
+		if err != nil {
+			fmt.Printf("Error loading configuration: %v\n", err)
+			return
+		}
+
+		logger := SetupLogger()
+		service, err := NewLaunchesService(config, logger)
+		if err != nil {
+			fmt.Printf("Error setting up API clients: %v\n", err)
+			return
+		}
+
+		start, end, err := asteroidDateRange(cmd)
+		if err != nil {
+			fmt.Printf("Error reading dates: %v\n", err)
+			return
+		}
+		filter, err := buildAsteroidFilter(cmd)
+		if err != nil {
+			fmt.Printf("Error reading filters: %v\n", err)
+			return
+		}
+
+		feed, err := service.GetAsteroidsBetween(ctx, start, end)
+		if err != nil {
+			logger.Error("failed to fetch asteroids", "error", err)
+			fmt.Printf("Error fetching asteroids: %v\n", err)
+			return
+		}
+
+		approaches := filter.apply(feed.Approaches())
+		total := len(approaches)
+		if limit, _ := cmd.Flags().GetInt("limit"); limit > 0 && limit < total {
+			approaches = approaches[:limit]
+		}
+
+		fmt.Printf("\n☄️  Near-Earth approaches %s to %s (showing %d of %d, %d objects in feed):\n",
+			start.Format(time.DateOnly), end.Format(time.DateOnly), len(approaches), total, feed.ElementCount)
+		fmt.Println(strings.Repeat("-", 80))
+		for _, approach := range approaches {
+			hazard := ""
+			if approach.Asteroid.Hazardous {
+				hazard = "  ⚠️  hazardous"
+			}
+			fmt.Printf("📅 %s  %s%s\n", approach.Approach.Date, approach.Asteroid.Name, hazard)
+			fmt.Printf("   📏 %.0f-%.0f m   🎯 %.0f km (%.2f lunar)   💨 %.1f km/s\n",
+				approach.Asteroid.Diameter.Meters.Min,
+				approach.Asteroid.Diameter.Meters.Max,
+				approach.Approach.MissDistance.Kilometers,
+				approach.Approach.MissDistance.Lunar,
+				approach.Approach.RelativeVelocity.KilometersPerSecond)
+		}
+	},
+}
+
+var asteroidsShowCmd = &cobra.Command{
+	Use:   "show <id>",
+	Short: "Show an asteroid's orbit and its past and future Earth approaches",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		config, err := LoadConfiguration()
+		if err != nil {
+			fmt.Printf("Error loading configuration: %v\n", err)
+			return
+		}
+
+		logger := SetupLogger()
+		service, err := NewLaunchesService(config, logger)
+		if err != nil {
+			fmt.Printf("Error setting up API clients: %v\n", err)
+			return
+		}
+
+		asteroid, err := service.GetAsteroid(ctx, args[0])
+		if err != nil {
+			var statusErr *api.StatusError
+			if errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusNotFound {
+				fmt.Printf("No asteroid found with ID %s\n", args[0])
+				return
+			}
+			logger.Error("failed to fetch asteroid", "error", err)
+			fmt.Printf("Error fetching asteroid: %v\n", err)
+			return
+		}
+
+		limit, _ := cmd.Flags().GetInt("limit")
+		printAsteroid(asteroid, time.Now(), limit)
+	},
+}
+
+func printAsteroid(asteroid model.NasaAsteroidObject, now time.Time, limit int) {
+	fmt.Printf("\n☄️  %s (NEO %s)\n", asteroid.Name, asteroid.NEOReferenceID)
+	fmt.Println(strings.Repeat("-", 80))
+	hazard := "no"
+	if asteroid.Hazardous {
+		hazard = "⚠️  yes"
+	}
+	fmt.Printf("   Potentially hazardous: %s\n", hazard)
+	fmt.Printf("   📏 Estimated diameter: %.0f-%.0f m\n", asteroid.Diameter.Meters.Min, asteroid.Diameter.Meters.Max)
+	fmt.Printf("   ✨ Absolute magnitude: %.2f\n", asteroid.AbsoluteMagnitude)
+
+	if orbit := asteroid.OrbitalData; orbit != nil {
+		fmt.Printf("   🪐 Orbit class: %s (%s)\n", orbit.OrbitClass.Type, orbit.OrbitClass.Description)
+		fmt.Printf("      Semi-major axis %.3f AU, eccentricity %.3f, inclination %.2f°\n", orbit.SemiMajorAxis, orbit.Eccentricity, orbit.Inclination)
+		fmt.Printf("      Period %.0f days, perihelion %.3f AU, aphelion %.3f AU\n", orbit.OrbitalPeriod, orbit.PerihelionDistance, orbit.AphelionDistance)
+	}
+
+	past, future := asteroid.EarthApproaches(now)
+	if limit > 0 {
+		past = past[max(0, len(past)-limit):]
+		future = future[:min(limit, len(future))]
+	}
+	printApproaches("Past Earth approaches", past)
+	printApproaches("Future Earth approaches", future)
+}
+
+func printApproaches(title string, approaches []model.CloseApproach) {
+	fmt.Printf("\n   %s:\n", title)
+	if len(approaches) == 0 {
+		fmt.Printf("      none on record\n")
+		return
+	}
+	for _, approach := range approaches {
+		fmt.Printf("      📅 %s  🎯 %.0f km (%.2f lunar)  💨 %.1f km/s\n",
+			approach.Date,
+			approach.MissDistance.Kilometers,
+			approach.MissDistance.Lunar,
+			approach.RelativeVelocity.KilometersPerSecond)
+	}
+}
+
+type dateRange struct {
+	start time.Time
+	end   time.Time
+}
+
+// asteroidFeedChunks splits the inclusive range start..end into consecutive
+// ranges the NeoWs feed accepts in a single request.
+func asteroidFeedChunks(start, end time.Time) []dateRange {
+	var chunks []dateRange
+	for chunkStart := start; !chunkStart.After(end); chunkStart = chunkStart.AddDate(0, 0, asteroidFeedMaxDays) {
+		chunkEnd := chunkStart.AddDate(0, 0, asteroidFeedMaxDays-1)
+		if chunkEnd.After(end) {
+			chunkEnd = end
+		}
+		chunks = append(chunks, dateRange{start: chunkStart, end: chunkEnd})
+	}
+	return chunks
+}
+
+func buildAsteroidsQueryParams(start, end time.Time) string {
+	return fmt.Sprintf("?start_date=%s&end_date=%s", start.Format("2006-01-02"), end.Format("2006-01-02"))
+}
+
+// asteroidDateRange reads --start and --end, defaulting to the week starting
+// today.
+func asteroidDateRange(cmd *cobra.Command) (time.Time, time.Time, error) {
+	startDate, _ := cmd.Flags().GetString("start")
+	endDate, _ := cmd.Flags().GetString("end")
+
+	start := time.Now().UTC().Truncate(24 * time.Hour)
+	if startDate != "" {
+		parsed, err := time.Parse(time.DateOnly, startDate)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid start date %q: %w", startDate, err)
+		}
+		start = parsed
+	}
+
+	end := start.AddDate(0, 0, asteroidFeedMaxDays-1)
+	if endDate != "" {
+		parsed, err := time.Parse(time.DateOnly, endDate)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid end date %q: %w", endDate, err)
+		}
+		end = parsed
+	}
+
+	if end.Before(start) {
+		return time.Time{}, time.Time{}, fmt.Errorf("end date %s is before start date %s", end.Format(time.DateOnly), start.Format(time.DateOnly))
+	}
+	return start, end, nil
+}
+
+// asteroidFilter selects and orders close approaches. Zero values disable a
+// filter.
+type asteroidFilter struct {
+	hazardousOnly   bool
+	minDiameter     float64
+	maxMissDistance float64
+	sortBy          string
+	descending      bool
+}
+
+func buildAsteroidFilter(cmd *cobra.Command) (asteroidFilter, error) {
+	var filter asteroidFilter
+	filter.hazardousOnly, _ = cmd.Flags().GetBool("hazardous")
+	filter.minDiameter, _ = cmd.Flags().GetFloat64("min-diameter")
+	filter.maxMissDistance, _ = cmd.Flags().GetFloat64("max-miss-distance")
+	filter.sortBy, _ = cmd.Flags().GetString("sort")
+	filter.descending, _ = cmd.Flags().GetBool("desc")
+
+	if filter.sortBy == "" {
+		filter.sortBy = "date"
+	}
+	if !slices.Contains(asteroidSortKeys, filter.sortBy) {
+		return asteroidFilter{}, fmt.Errorf("unknown sort key %q (want one of %s)", filter.sortBy, strings.Join(asteroidSortKeys, ", "))
+	}
+	return filter, nil
+}
+
+func (f asteroidFilter) apply(approaches []model.AsteroidApproach) []model.AsteroidApproach {
+	var selected []model.AsteroidApproach
+	for _, approach := range approaches {
+		if f.hazardousOnly && !approach.Asteroid.Hazardous {
+			continue
+		}
+		if f.minDiameter > 0 && approach.Asteroid.Diameter.Meters.Max < f.minDiameter {
+			continue
+		}
+		if f.maxMissDistance > 0 && approach.Approach.MissDistance.Kilometers > f.maxMissDistance {
+			continue
+		}
+		selected = append(selected, approach)
+	}
+
+	slices.SortStableFunc(selected, func(a, b model.AsteroidApproach) int {
+		var c int
+		switch f.sortBy {
+		case "date":
+			c = a.Approach.Time().Compare(b.Approach.Time())
+		case "miss-distance":
+			c = cmp.Compare(a.Approach.MissDistance.Kilometers, b.Approach.MissDistance.Kilometers)
+		case "diameter":
+			c = cmp.Compare(a.Asteroid.Diameter.Meters.Max, b.Asteroid.Diameter.Meters.Max)
+		case "velocity":
+			c = cmp.Compare(a.Approach.RelativeVelocity.KilometersPerSecond, b.Approach.RelativeVelocity.KilometersPerSecond)
+		case "name":
+			c = strings.Compare(a.Asteroid.Name, b.Asteroid.Name)
+		}
+		if f.descending {
+			c = -c
+		}
+		return c
+	})
+	return selected
+}
+
+func init() {
+	rootCmd.AddCommand(asteroidsCmd)
+	asteroidsCmd.AddCommand(asteroidsShowCmd)
+
+	asteroidsShowCmd.Flags().IntP("limit", "l", 5, "Number of past and future approaches to show (0 shows all)")
+
+	asteroidsCmd.Flags().StringP("start", "s", "", "Start date (YYYY-MM-DD, default today)")
+	asteroidsCmd.Flags().StringP("end", "e", "", "End date (YYYY-MM-DD, default 6 days after start)")
+	asteroidsCmd.Flags().Bool("hazardous", false, "Only show potentially hazardous asteroids")
+	asteroidsCmd.Flags().Float64("min-diameter", 0, "Minimum estimated diameter in meters")
+	asteroidsCmd.Flags().Float64("max-miss-distance", 0, "Maximum miss distance in kilometers")
+	asteroidsCmd.Flags().String("sort", "date", "Sort by "+strings.Join(asteroidSortKeys, ", "))
+	asteroidsCmd.Flags().Bool("desc", false, "Sort in descending order")
+	asteroidsCmd.Flags().IntP("limit", "l", 0, "Number of approaches to show (0 shows all)")
+}
@@ -0,0 +1,168 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/MitiaRD/ReMarkable-cli/api/apitest"
+	"github.com/MitiaRD/ReMarkable-cli/model"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mustDate(s string) time.Time {
+	t, _ := time.Parse(time.DateOnly, s)
+	return t
+}
+
+func TestAsteroidFeedChunks(t *testing.T) {
+	tests := []struct {
+		name  string
+		start string
+		end   string
+		want  []string
+	}{
+		{
+			name:  "single day",
+			start: "2024-01-01",
+			end:   "2024-01-01",
+			want:  []string{"2024-01-01..2024-01-01"},
+		},
+		{
+			name:  "exactly one week",
+			start: "2024-01-01",
+			end:   "2024-01-07",
+			want:  []string{"2024-01-01..2024-01-07"},
+		},
+		{
+			name:  "across a month boundary",
+			start: "2024-01-28",
+			end:   "2024-02-12",
+			want:  []string{"2024-01-28..2024-02-03", "2024-02-04..2024-02-10", "2024-02-11..2024-02-12"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, chunk := range asteroidFeedChunks(mustDate(tt.start), mustDate(tt.end)) {
+				got = append(got, chunk.start.Format(time.DateOnly)+".."+chunk.end.Format(time.DateOnly))
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func neo(name string, day string, hazardous bool, diameter, missKm, velocity float64) model.NasaAsteroidObject {
+	asteroid := model.NasaAsteroidObject{ID: name, Name: name, Hazardous: hazardous}
+	asteroid.Diameter.Meters.Max = diameter
+	approach := model.CloseApproach{Date: day, OrbitingBody: "Earth"}
+	approach.MissDistance.Kilometers = missKm
+	approach.RelativeVelocity.KilometersPerSecond = velocity
+	asteroid.CloseApproaches = []model.CloseApproach{approach}
+	return asteroid
+}
+
+func TestGetAsteroidsBetweenMergesChunks(t *testing.T) {
+	nasa := apitest.NewNASA().
+		WithAsteroids(mustDate("2024-01-01"), neo("a", "2024-01-01", false, 10, 100, 5)).
+		WithAsteroids(mustDate("2024-01-09"), neo("b", "2024-01-09", true, 20, 50, 7)).
+		WithAsteroids(mustDate("2024-01-20"), neo("c", "2024-01-20", false, 30, 10, 9))
+	service := NewLaunchesServiceWithClients(Clients{SpaceX: apitest.NewSpaceX(), NASA: nasa}, model.DefaultConfig(), slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	feed, err := service.GetAsteroidsBetween(context.Background(), mustDate("2024-01-01"), mustDate("2024-01-20"))
+	require.NoError(t, err)
+	assert.Equal(t, 3, feed.ElementCount)
+	assert.Len(t, feed.NearEarthObjects, 20)
+	assert.Len(t, nasa.Calls(), 3)
+
+	var names []string
+	for _, approach := range feed.Approaches() {
+		names = append(names, approach.Asteroid.Name)
+	}
+	assert.Equal(t, []string{"a", "b", "c"}, names)
+}
+
+func TestGetAsteroidsBetweenReportsChunkErrors(t *testing.T) {
+	nasa := apitest.NewNASA().FailAll(errors.New("NeoWs down"))
+	service := NewLaunchesServiceWithClients(Clients{SpaceX: apitest.NewSpaceX(), NASA: nasa}, model.DefaultConfig(), slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	_, err := service.GetAsteroidsBetween(context.Background(), mustDate("2024-01-01"), mustDate("2024-01-10"))
+	assert.EqualError(t, err, "failed to fetch asteroids for 2024-01-01 to 2024-01-07: NeoWs down")
+}
+
+func TestAsteroidFilter(t *testing.T) {
+	feed := model.NasaAsteroid{NearEarthObjects: map[string][]model.NasaAsteroidObject{
+		"2024-01-01": {neo("small", "2024-01-01", false, 10, 300, 5)},
+		"2024-01-02": {neo("big", "2024-01-02", true, 500, 200, 20)},
+		"2024-01-03": {neo("medium", "2024-01-03", true, 100, 100, 10)},
+	}}
+
+	tests := []struct {
+		name  string
+		flags map[string]string
+		want  []string
+	}{
+		{
+			name: "defaults sort by date",
+			want: []string{"small", "big", "medium"},
+		},
+		{
+			name:  "hazardous only",
+			flags: map[string]string{"hazardous": "true"},
+			want:  []string{"big", "medium"},
+		},
+		{
+			name:  "min diameter",
+			flags: map[string]string{"min-diameter": "50"},
+			want:  []string{"big", "medium"},
+		},
+		{
+			name:  "max miss distance sorted by miss distance",
+			flags: map[string]string{"max-miss-distance": "250", "sort": "miss-distance"},
+			want:  []string{"medium", "big"},
+		},
+		{
+			name:  "largest first",
+			flags: map[string]string{"sort": "diameter", "desc": "true"},
+			want:  []string{"big", "medium", "small"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &cobra.Command{}
+			cmd.Flags().Bool("hazardous", false, "")
+			cmd.Flags().Float64("min-diameter", 0, "")
+			cmd.Flags().Float64("max-miss-distance", 0, "")
+			cmd.Flags().String("sort", "date", "")
+			cmd.Flags().Bool("desc", false, "")
+			for name, value := range tt.flags {
+				require.NoError(t, cmd.Flags().Set(name, value))
+			}
+
+			filter, err := buildAsteroidFilter(cmd)
+			require.NoError(t, err)
+
+			var names []string
+			for _, approach := range filter.apply(feed.Approaches()) {
+				names = append(names, approach.Asteroid.Name)
+			}
+			assert.Equal(t, tt.want, names)
+		})
+	}
+}
+
+func TestAsteroidFilterRejectsUnknownSort(t *testing.T) {
+	cmd := &cobra.Command{}
+	cmd.Flags().String("sort", "", "")
+	cmd.Flags().Set("sort", "mass")
+
+	_, err := buildAsteroidFilter(cmd)
+	assert.Error(t, err)
+}
@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Inspect and manage the on-disk response cache",
+	Long: `Cache manages the responses stored between runs.
+
+Available subcommands:
+  stats        - Show entry counts and size per resource class,
+  ls           - List cached responses,
+  clear        - Remove every cached response`,
+}
+
+var cacheStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show cache statistics",
+	Run: func(cmd *cobra.Command, args []string) {
+		cache, err := OpenCache(readConfiguration())
+		if err != nil {
+			fmt.Printf("Error opening cache: %v\n", err)
+			return
+		}
+
+		stats, err := cache.Stats()
+		if err != nil {
+			fmt.Printf("Error reading cache: %v\n", err)
+			return
+		}
+
+		fmt.Printf("\n🗄️  Cache: %s\n", stats.Dir)
+		fmt.Println(strings.Repeat("-", 80))
+		fmt.Printf("   Entries: %d (%d expired)\n", stats.Entries, stats.Expired)
+		fmt.Printf("   Size:    %.1f KiB\n", float64(stats.Bytes)/1024)
+		for _, class := range slices.Sorted(maps.Keys(stats.ByClass)) {
+			fmt.Printf("   %-9s %d (ttl %s)\n", class+":", stats.ByClass[class], cache.TTL(class))
+		}
+	},
+}
+
+var cacheLsCmd = &cobra.Command{
+	Use:   "ls",
+	Short: "List cached responses",
+	Run: func(cmd *cobra.Command, args []string) {
+		cache, err := OpenCache(readConfiguration())
+		if err != nil {
+			fmt.Printf("Error opening cache: %v\n", err)
+			return
+		}
+
+		entries, err := cache.Entries()
+		if err != nil {
+			fmt.Printf("Error reading cache: %v\n", err)
+			return
+		}
+
+		fmt.Printf("\n🗄️  Cached responses (%d):\n", len(entries))
+		fmt.Println(strings.Repeat("-", 80))
+		for _, entry := range entries {
+			status := "fresh"
+			if !cache.Fresh(&entry) {
+				status = "expired"
+			}
+			age := time.Since(entry.StoredAt).Truncate(time.Second)
+			fmt.Printf("%s  %-9s %-7s %8s  %s %s\n", entry.Key[:min(12, len(entry.Key))], entry.Class, status, age, entry.Method, entry.URL)
+		}
+	},
+}
+
+var cacheClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Remove every cached response",
+	Run: func(cmd *cobra.Command, args []string) {
+		cache, err := OpenCache(readConfiguration())
+		if err != nil {
+			fmt.Printf("Error opening cache: %v\n", err)
+			return
+		}
+
+		removed, err := cache.Clear()
+		if err != nil {
+			fmt.Printf("Error clearing cache: %v\n", err)
+			return
+		}
+		fmt.Printf("🧹 Removed %d cached responses from %s\n", removed, cache.Dir())
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(cacheCmd)
+	cacheCmd.AddCommand(cacheStatsCmd)
+	cacheCmd.AddCommand(cacheLsCmd)
+	cacheCmd.AddCommand(cacheClearCmd)
+}
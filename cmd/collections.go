@@ -0,0 +1,469 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/MitiaRD/ReMarkable-cli/api"
+	"github.com/MitiaRD/ReMarkable-cli/model"
+	"github.com/spf13/cobra"
+)
+
+// objectIDPattern matches the MongoDB IDs used by the SpaceX API.
+var objectIDPattern = regexp.MustCompile(`^[0-9a-f]{24}$`)
+
+// collectionFilter maps a list flag onto a query field.
+type collectionFilter struct {
+	flag  string
+	field string
+	usage string
+	// isBool makes the flag a boolean; other filters take a string.
+	isBool bool
+}
+
+// collection describes the list and show subcommands of one SpaceX
+// collection such as payloads or cores.
+type collection[T any] struct {
+	name     string
+	singular string
+	short    string
+	// lookupField lets show accept e.g. a core serial instead of an ID.
+	lookupField string
+	defaultSort string
+	filters     []collectionFilter
+
+	query func(*LaunchesService, context.Context, *api.CollectionQuery) (api.Page[T], error)
+	get   func(*LaunchesService, context.Context, string) (T, error)
+	// listFlags and extendQuery add filters that need more than a field
+	// match.
+	listFlags   func(*cobra.Command)
+	extendQuery func(context.Context, *LaunchesService, *cobra.Command, *api.CollectionQuery) error
+	printRow    func(T)
+	printDetail func(context.Context, *LaunchesService, T)
+}
+
+func (c collection[T]) command() *cobra.Command {
+	parent := &cobra.Command{
+		Use:   c.name,
+		Short: c.short,
+		Long: fmt.Sprintf(`%s
+
+Available subcommands:
+  list         - List %s,
+  show         - Show a single %s by ID or %s`, c.short, c.name, c.singular, c.lookupField),
+	}
+
+	list := &cobra.Command{
+		Use:   "list",
+		Short: "List " + c.name,
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+
+			config, err := LoadConfiguration()
+			if err != nil {
+				fmt.Printf("Error loading configuration: %v\n", err)
+				return
+			}
+
+			logger := SetupLogger()
+			service, err := NewLaunchesService(config, logger)
+			if err != nil {
+				fmt.Printf("Error setting up API clients: %v\n", err)
+				return
+			}
+
+			query, err := c.buildQuery(ctx, service, cmd)
+			if err != nil {
+				fmt.Printf("Error building %s query: %v\n", c.singular, err)
+				return
+			}
+
+			var docs []T
+			var page api.Page[T]
+			if all, _ := cmd.Flags().GetBool("all"); all {
+				for doc, iterErr := range api.IterateCollection(ctx, query, func(ctx context.Context, query *api.CollectionQuery) (api.Page[T], error) {
+					return c.query(service, ctx, query)
+				}) {
+					if iterErr != nil {
+						err = iterErr
+						break
+					}
+					docs = append(docs, doc)
+				}
+			} else {
+				page, err = c.query(service, ctx, query)
+				docs = page.Docs
+			}
+			if err != nil {
+				logger.Error("failed to fetch "+c.name, "error", err)
+				fmt.Printf("Error fetching %s: %v\n", c.name, err)
+				return
+			}
+
+			fmt.Printf("\n%s (showing %d):\n", strings.ToUpper(c.name[:1])+c.name[1:], len(docs))
+			if page.HasNextPage {
+				fmt.Printf("   %d %s match in total (page %d of %d); use --page %d or --all to see more\n", page.TotalDocs, c.name, page.Page, page.TotalPages, page.Page+1)
+			}
+			fmt.Println(strings.Repeat("-", 80))
+			for _, doc := range docs {
+				c.printRow(doc)
+			}
+		},
+	}
+	list.Flags().IntP("limit", "l", 20, "Number of "+c.name+" to show (page size with --all)")
+	list.Flags().Int("page", 0, "Page of results to show, starting at 1")
+	list.Flags().Bool("all", false, "Fetch every matching "+c.singular+" across all pages")
+	list.Flags().String("sort", c.defaultSort, "Field to sort by")
+	list.Flags().Bool("desc", false, "Sort in descending order")
+	for _, filter := range c.filters {
+		if filter.isBool {
+			list.Flags().Bool(filter.flag, false, filter.usage)
+		} else {
+			list.Flags().String(filter.flag, "", filter.usage)
+		}
+	}
+	if c.listFlags != nil {
+		c.listFlags(list)
+	}
+
+	show := &cobra.Command{
+		Use:   fmt.Sprintf("show <id|%s>", c.lookupField),
+		Short: fmt.Sprintf("Show a %s by ID or %s", c.singular, c.lookupField),
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+
+			config, err := LoadConfiguration()
+			if err != nil {
+				fmt.Printf("Error loading configuration: %v\n", err)
+				return
+			}
+
+			logger := SetupLogger()
+			service, err := NewLaunchesService(config, logger)
+			if err != nil {
+				fmt.Printf("Error setting up API clients: %v\n", err)
+				return
+			}
+
+			doc, found, err := c.find(ctx, service, args[0])
+			if err != nil {
+				logger.Error("failed to fetch "+c.singular, "error", err)
+				fmt.Printf("Error fetching %s: %v\n", c.singular, err)
+				return
+			}
+			if !found {
+				fmt.Printf("No %s found with ID or %s %s\n", c.singular, c.lookupField, args[0])
+				return
+			}
+			c.printDetail(ctx, service, doc)
+		},
+	}
+
+	parent.AddCommand(list, show)
+	return parent
+}
+
+func (c collection[T]) buildQuery(ctx context.Context, service *LaunchesService, cmd *cobra.Command) (*api.CollectionQuery, error) {
+	query := api.NewCollectionQuery()
+	for _, filter := range c.filters {
+		if !cmd.Flags().Changed(filter.flag) {
+			continue
+		}
+		if filter.isBool {
+			value, _ := cmd.Flags().GetBool(filter.flag)
+			query.Where(filter.field, value)
+		} else {
+			value, _ := cmd.Flags().GetString(filter.flag)
+			query.Where(filter.field, value)
+		}
+	}
+	if c.extendQuery != nil {
+		if err := c.extendQuery(ctx, service, cmd, query); err != nil {
+			return nil, err
+		}
+	}
+
+	if sort, _ := cmd.Flags().GetString("sort"); sort != "" {
+		order := api.Ascending
+		if desc, _ := cmd.Flags().GetBool("desc"); desc {
+			order = api.Descending
+		}
+		query.SortBy(sort, order)
+	}
+	if limit, _ := cmd.Flags().GetInt("limit"); limit > 0 {
+		query.Limit(limit)
+	}
+	if page, _ := cmd.Flags().GetInt("page"); page > 0 {
+		query.Page(page)
+	}
+	return query, nil
+}
+
+// find looks a document up by ID, falling back to the lookup field for
+// anything that is not an ID.
+func (c collection[T]) find(ctx context.Context, service *LaunchesService, key string) (T, bool, error) {
+	var zero T
+	if objectIDPattern.MatchString(key) {
+		doc, err := c.get(service, ctx, key)
+		var statusErr *api.StatusError
+		if errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusNotFound {
+			return zero, false, nil
+		}
+		return doc, err == nil, err
+	}
+
+	page, err := c.query(service, ctx, api.NewCollectionQuery().Where(c.lookupField, key).Limit(1))
+	if err != nil || len(page.Docs) == 0 {
+		return zero, false, err
+	}
+	return page.Docs[0], true, nil
+}
+
+var payloadsCollection = collection[model.Payload]{
+	name:        "payloads",
+	singular:    "payload",
+	short:       "Explore the payloads SpaceX has launched",
+	lookupField: "name",
+	defaultSort: "name",
+	filters: []collectionFilter{
+		{flag: "type", field: "type", usage: "Payload type, e.g. Satellite or Crew Dragon"},
+		{flag: "orbit", field: "orbit", usage: "Orbit, e.g. LEO, GTO or ISS"},
+		{flag: "customer", field: "customers", usage: "Customer, e.g. NASA"},
+		{flag: "launch", field: "launch", usage: "Launch ID"},
+	},
+	query: (*LaunchesService).QueryPayloads,
+	get:   (*LaunchesService).GetPayload,
+	listFlags: func(cmd *cobra.Command) {
+		cmd.Flags().String("core", "", "Only payloads carried by this core, by ID or serial")
+	},
+	extendQuery: func(ctx context.Context, service *LaunchesService, cmd *cobra.Command, query *api.CollectionQuery) error {
+		serial, _ := cmd.Flags().GetString("core")
+		if serial == "" {
+			return nil
+		}
+		core, found, err := coresCollection.find(ctx, service, serial)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return fmt.Errorf("no core found with ID or serial %s", serial)
+		}
+		query.In("launch", core.Launches...)
+		return nil
+	},
+	printRow: func(payload model.Payload) {
+		fmt.Printf("📦 %s (%s)\n", payload.Name, payload.Type)
+		fmt.Printf("   🛰️  %s  ⚖️  %s  👤 %s\n", orUnknown(payload.Orbit), formatMass(payload.MassKg), orUnknown(strings.Join(payload.Customers, ", ")))
+	},
+	printDetail: func(ctx context.Context, service *LaunchesService, payload model.Payload) {
+		printPayload(payload)
+	},
+}
+
+var coresCollection = collection[model.Core]{
+	name:        "cores",
+	singular:    "core",
+	short:       "Explore the first stage boosters SpaceX has flown",
+	lookupField: "serial",
+	defaultSort: "serial",
+	filters: []collectionFilter{
+		{flag: "status", field: "status", usage: "Status: active, inactive, unknown, expended, lost or retired"},
+	},
+	query: (*LaunchesService).QueryCores,
+	get:   (*LaunchesService).GetCore,
+	printRow: func(core model.Core) {
+		fmt.Printf("🔩 %s (%s)  block %s  flights %d  landings %d/%d\n",
+			core.Serial, core.Status, formatBlock(core.Block), len(core.Launches),
+			core.RTLSLandings+core.ASDSLandings, core.RTLSAttempts+core.ASDSAttempts)
+	},
+	printDetail: func(ctx context.Context, service *LaunchesService, core model.Core) {
+		fmt.Printf("\n🔩 Core %s\n", core.Serial)
+		fmt.Println(strings.Repeat("-", 80))
+		fmt.Printf("   Status: %s, block %s, reused %d times\n", core.Status, formatBlock(core.Block), core.ReuseCount)
+		fmt.Printf("   Landings: RTLS %d/%d, drone ship %d/%d\n", core.RTLSLandings, core.RTLSAttempts, core.ASDSLandings, core.ASDSAttempts)
+		if core.LastUpdate != "" {
+			fmt.Printf("   ℹ️ %s\n", core.LastUpdate)
+		}
+		fmt.Printf("   🚀 %d flights\n", len(core.Launches))
+
+		payloads, err := service.GetCorePayloads(ctx, core)
+		if err != nil {
+			service.logger.Error("failed to fetch payloads", "error", err)
+			fmt.Printf("   📦 Payloads unavailable: %s\n", unavailableReason(err))
+			return
+		}
+		for _, payload := range payloads {
+			fmt.Printf("   📦 %s (%s, %s)\n", payload.Name, payload.Type, orUnknown(payload.Orbit))
+		}
+	},
+}
+
+var capsulesCollection = collection[model.Capsule]{
+	name:        "capsules",
+	singular:    "capsule",
+	short:       "Explore the Dragon capsules SpaceX has flown",
+	lookupField: "serial",
+	defaultSort: "serial",
+	filters: []collectionFilter{
+		{flag: "status", field: "status", usage: "Status: unknown, active, retired or destroyed"},
+		{flag: "type", field: "type", usage: "Type: Dragon 1.0, Dragon 1.1 or Dragon 2.0"},
+	},
+	query: (*LaunchesService).QueryCapsules,
+	get:   (*LaunchesService).GetCapsule,
+	printRow: func(capsule model.Capsule) {
+		fmt.Printf("🛸 %s (%s, %s)  flights %d  landings %d\n",
+			capsule.Serial, capsule.Type, capsule.Status, len(capsule.Launches), capsule.WaterLandings+capsule.LandLandings)
+	},
+	printDetail: func(ctx context.Context, service *LaunchesService, capsule model.Capsule) {
+		fmt.Printf("\n🛸 Capsule %s (%s)\n", capsule.Serial, capsule.Type)
+		fmt.Println(strings.Repeat("-", 80))
+		fmt.Printf("   Status: %s, reused %d times\n", capsule.Status, capsule.ReuseCount)
+		fmt.Printf("   Landings: %d on water, %d on land\n", capsule.WaterLandings, capsule.LandLandings)
+		if capsule.LastUpdate != "" {
+			fmt.Printf("   ℹ️ %s\n", capsule.LastUpdate)
+		}
+		fmt.Printf("   🚀 %d flights\n", len(capsule.Launches))
+	},
+}
+
+var shipsCollection = collection[model.Ship]{
+	name:        "ships",
+	singular:    "ship",
+	short:       "Explore SpaceX's recovery, support and drone ships",
+	lookupField: "name",
+	defaultSort: "name",
+	filters: []collectionFilter{
+		{flag: "active", field: "active", usage: "Only show active ships", isBool: true},
+		{flag: "type", field: "type", usage: "Type, e.g. Barge, Cargo or Tug"},
+		{flag: "role", field: "roles", usage: "Role, e.g. ASDS barge or Fairing Recovery"},
+	},
+	query: (*LaunchesService).QueryShips,
+	get:   (*LaunchesService).GetShip,
+	printRow: func(ship model.Ship) {
+		active := "inactive"
+		if ship.Active {
+			active = "active"
+		}
+		fmt.Printf("🚢 %s (%s, %s)  %s  launches %d\n", ship.Name, orUnknown(ship.Type), active, strings.Join(ship.Roles, ", "), len(ship.Launches))
+	},
+	printDetail: func(ctx context.Context, service *LaunchesService, ship model.Ship) {
+		fmt.Printf("\n🚢 %s\n", ship.Name)
+		fmt.Println(strings.Repeat("-", 80))
+		fmt.Printf("   Type: %s, roles: %s\n", orUnknown(ship.Type), orUnknown(strings.Join(ship.Roles, ", ")))
+		fmt.Printf("   Active: %t, home port: %s\n", ship.Active, orUnknown(ship.HomePort))
+		if ship.YearBuilt != nil {
+			fmt.Printf("   Built: %d\n", *ship.YearBuilt)
+		}
+		fmt.Printf("   ⚖️  %s\n", formatMass(ship.MassKg))
+		if ship.Latitude != nil && ship.Longitude != nil {
+			fmt.Printf("   📍 %.4f, %.4f\n", *ship.Latitude, *ship.Longitude)
+		}
+		if ship.Link != "" {
+			fmt.Printf("   🔗 %s\n", ship.Link)
+		}
+		fmt.Printf("   🚀 %d launches supported\n", len(ship.Launches))
+	},
+}
+
+var landpadsCollection = collection[model.Landpad]{
+	name:        "landpads",
+	singular:    "landpad",
+	short:       "Explore SpaceX's landing zones and drone ship landing sites",
+	lookupField: "name",
+	defaultSort: "name",
+	filters: []collectionFilter{
+		{flag: "status", field: "status", usage: "Status, e.g. active or retired"},
+		{flag: "type", field: "type", usage: "Type: RTLS or ASDS"},
+	},
+	query: (*LaunchesService).QueryLandpads,
+	get:   (*LaunchesService).GetLandpad,
+	printRow: func(landpad model.Landpad) {
+		fmt.Printf("🛬 %s (%s, %s)  %s  landings %d/%d\n",
+			landpad.Name, landpad.Type, landpad.Status, landpad.FullName, landpad.LandingSuccesses, landpad.LandingAttempts)
+	},
+	printDetail: func(ctx context.Context, service *LaunchesService, landpad model.Landpad) {
+		fmt.Printf("\n🛬 %s (%s)\n", landpad.FullName, landpad.Name)
+		fmt.Println(strings.Repeat("-", 80))
+		fmt.Printf("   Type: %s, status: %s\n", landpad.Type, landpad.Status)
+		fmt.Printf("   📍 %s, %s (%.4f, %.4f)\n", landpad.Locality, landpad.Region, landpad.Latitude, landpad.Longitude)
+		fmt.Printf("   Landings: %d of %d successful\n", landpad.LandingSuccesses, landpad.LandingAttempts)
+		if landpad.Details != "" {
+			fmt.Printf("   ℹ️ %s\n", landpad.Details)
+		}
+		if landpad.Wikipedia != "" {
+			fmt.Printf("   🔗 %s\n", landpad.Wikipedia)
+		}
+	},
+}
+
+func printPayload(payload model.Payload) {
+	fmt.Printf("\n📦 %s (%s)\n", payload.Name, payload.Type)
+	fmt.Println(strings.Repeat("-", 80))
+	fmt.Printf("   Customers: %s\n", orUnknown(strings.Join(payload.Customers, ", ")))
+	fmt.Printf("   Manufacturers: %s\n", orUnknown(strings.Join(payload.Manufacturers, ", ")))
+	fmt.Printf("   Nationalities: %s\n", orUnknown(strings.Join(payload.Nationalities, ", ")))
+	fmt.Printf("   ⚖️  %s, reused: %t\n", formatMass(payload.MassKg), payload.Reused)
+	fmt.Printf("   🛰️  %s orbit (%s, %s)\n", orUnknown(payload.Orbit), orUnknown(payload.Regime), orUnknown(payload.ReferenceSystem))
+	if payload.PeriapsisKm != nil && payload.ApoapsisKm != nil {
+		fmt.Printf("      %.0f x %.0f km", *payload.PeriapsisKm, *payload.ApoapsisKm)
+		if payload.InclinationDeg != nil {
+			fmt.Printf(", %.1f°", *payload.InclinationDeg)
+		}
+		if payload.PeriodMin != nil {
+			fmt.Printf(", period %.1f min", *payload.PeriodMin)
+		}
+		fmt.Println()
+	}
+	if len(payload.NoradIDs) > 0 {
+		ids := make([]string, len(payload.NoradIDs))
+		for i, id := range payload.NoradIDs {
+			ids[i] = fmt.Sprint(id)
+		}
+		fmt.Printf("   NORAD IDs: %s\n", strings.Join(ids, ", "))
+	}
+	if payload.Dragon.CapsuleID != "" {
+		fmt.Printf("   🛸 Dragon capsule %s\n", payload.Dragon.CapsuleID)
+	}
+	if payload.LaunchID != "" {
+		fmt.Printf("   🚀 Launch %s\n", payload.LaunchID)
+	}
+}
+
+func formatMass(kg *float64) string {
+	if kg == nil {
+		return "mass unknown"
+	}
+	return fmt.Sprintf("%.0f kg", *kg)
+}
+
+func formatBlock(block *int) string {
+	if block == nil {
+		return "?"
+	}
+	return fmt.Sprint(*block)
+}
+
+func orUnknown(value string) string {
+	if value == "" {
+		return "unknown"
+	}
+	return value
+}
+
+func init() {
+	rootCmd.AddCommand(
+		payloadsCollection.command(),
+		coresCollection.command(),
+		capsulesCollection.command(),
+		shipsCollection.command(),
+		landpadsCollection.command(),
+	)
+}
@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/MitiaRD/ReMarkable-cli/api/apitest"
+	"github.com/MitiaRD/ReMarkable-cli/model"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func collectionsService() (*LaunchesService, *apitest.SpaceX) {
+	spaceX := apitest.NewSpaceX().
+		WithCores(
+			model.Core{ID: "5e9e28a6f35918c0803b265c", Serial: "B1049", Status: "lost", Launches: []string{"l1", "l2"}},
+			model.Core{ID: "5e9e28a7f359187afd3b2662", Serial: "B1051", Status: "active", Launches: []string{"l3"}},
+		).
+		WithPayloads(
+			model.Payload{ID: "p1", Name: "Telstar 18V", Type: "Satellite", LaunchID: "l1", Orbit: "GTO"},
+			model.Payload{ID: "p2", Name: "Iridium NEXT 7", Type: "Satellite", LaunchID: "l2", Orbit: "PO"},
+			model.Payload{ID: "p3", Name: "Crew-1", Type: "Crew Dragon", LaunchID: "l3", Orbit: "ISS"},
+		)
+	return NewLaunchesServiceWithClients(Clients{SpaceX: spaceX}, model.DefaultConfig(), slog.New(slog.NewTextHandler(io.Discard, nil))), spaceX
+}
+
+func TestCollectionFind(t *testing.T) {
+	service, _ := collectionsService()
+	ctx := context.Background()
+
+	core, found, err := coresCollection.find(ctx, service, "B1051")
+	require.NoError(t, err)
+	require.True(t, found)
+	assert.Equal(t, "5e9e28a7f359187afd3b2662", core.ID)
+
+	core, found, err = coresCollection.find(ctx, service, "5e9e28a6f35918c0803b265c")
+	require.NoError(t, err)
+	require.True(t, found)
+	assert.Equal(t, "B1049", core.Serial)
+
+	_, found, err = coresCollection.find(ctx, service, "000000000000000000000000")
+	require.NoError(t, err)
+	assert.False(t, found, "unknown IDs are not found rather than failing")
+
+	_, found, err = coresCollection.find(ctx, service, "B9999")
+	require.NoError(t, err)
+	assert.False(t, found)
+}
+
+func TestPayloadsCarriedByCore(t *testing.T) {
+	service, _ := collectionsService()
+	ctx := context.Background()
+
+	cmd := &cobra.Command{}
+	cmd.Flags().IntP("limit", "l", 20, "")
+	cmd.Flags().Int("page", 0, "")
+	cmd.Flags().String("sort", "name", "")
+	cmd.Flags().Bool("desc", false, "")
+	for _, filter := range payloadsCollection.filters {
+		cmd.Flags().String(filter.flag, "", "")
+	}
+	payloadsCollection.listFlags(cmd)
+	require.NoError(t, cmd.Flags().Set("core", "B1049"))
+
+	query, err := payloadsCollection.buildQuery(ctx, service, cmd)
+	require.NoError(t, err)
+	page, err := service.QueryPayloads(ctx, query)
+	require.NoError(t, err)
+
+	var names []string
+	for _, payload := range page.Docs {
+		names = append(names, payload.Name)
+	}
+	assert.Equal(t, []string{"Iridium NEXT 7", "Telstar 18V"}, names)
+
+	core, _, err := coresCollection.find(ctx, service, "B1051")
+	require.NoError(t, err)
+	payloads, err := service.GetCorePayloads(ctx, core)
+	require.NoError(t, err)
+	require.Len(t, payloads, 1)
+	assert.Equal(t, "Crew-1", payloads[0].Name)
+
+	require.NoError(t, cmd.Flags().Set("core", "B0000"))
+	_, err = payloadsCollection.buildQuery(ctx, service, cmd)
+	assert.EqualError(t, err, "no core found with ID or serial B0000")
+}
@@ -5,13 +5,18 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/MitiaRD/ReMarkable-cli/api"
 	"github.com/MitiaRD/ReMarkable-cli/model"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 )
 
 var launchesCmd = &cobra.Command{
@@ -24,8 +29,22 @@ Available subcommands:
   start        - Start date (YYYY-MM-DD),
   end          - End date (YYYY-MM-DD),
   failed       - Filter for failed launches only,
-  upcoming     - Filter for upcoming launches only`,
+  upcoming     - Filter for upcoming launches only,
+  page         - Page of results to show,
+  offset       - Number of matching launches to skip,
+  all          - Fetch every matching launch across all pages,
+  populate     - Resolve rockets, launchpads, crew, cores and payloads in the launch request,
+  space-weather - Summarise solar flares, CMEs, storms and SEP events around each launch,
+  apod         - Show the Astronomy Picture of the Day for each launch date,
+  apod-dir     - Download each launch date's Astronomy Picture of the Day to a directory,
+  apod-hd      - Download high resolution pictures with apod-dir,
+  apod-thumbs  - Show thumbnail URLs for APOD videos`,
+	PreRun: func(cmd *cobra.Command, args []string) {
+		viper.BindPFlag("space_weather_days", cmd.Flags().Lookup("space-weather-days"))
+	},
 	Run: func(cmd *cobra.Command, args []string) {
+		// ctx covers fetching the launches and their reference data; the
+		// per-launch lookups get a launchTimeout each.
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
 
@@ -36,11 +55,26 @@ Available subcommands:
 		}
 
 		logger := SetupLogger()
-		service := NewLaunchesService(config, logger)
+		service, err := NewLaunchesService(config, logger)
+		if err != nil {
+			fmt.Printf("Error setting up API clients: %v\n", err)
+			return
+		}
 
-		query := buildLaunchQuery(cmd)
+		query, err := buildLaunchQuery(cmd)
+		if err != nil {
+			fmt.Printf("Error building launch query: %v\n", err)
+			return
+		}
 
-		launches, err := service.GetLaunches(ctx, query)
+		var launches []model.Launch
+		var page api.Page[model.Launch]
+		if all, _ := cmd.Flags().GetBool("all"); all {
+			launches, err = service.GetAllLaunches(ctx, query)
+		} else {
+			page, err = service.QueryLaunches(ctx, query)
+			launches = page.Docs
+		}
 		if err != nil {
 			logger.Error("failed to fetch launches", "error", err)
 			fmt.Printf("Error fetching launches: %v\n", err)
@@ -48,10 +82,24 @@ Available subcommands:
 		}
 
 		fmt.Printf("\n🚀 Launches (showing %d):\n", len(launches))
+		if page.HasNextPage {
+			fmt.Printf("   %d launches match in total (page %d of %d); use --page %d or --all to see more\n", page.TotalDocs, page.Page, page.TotalPages, page.Page+1)
+		}
 
-		rockets, err := service.GetRockets(ctx)
-		if err != nil {
-			logger.Error("failed to fetch rockets", "error", err)
+		// With --populate the reference data arrives embedded in the launches,
+		// as it always does from Launch Library 2, so the separate rocket,
+		// crew and launchpad lookups are skipped.
+		populate, _ := cmd.Flags().GetBool("populate")
+		populate = populate || referencesEmbedded(launches)
+		rockets, crewMap, launchpads := populatedReferences(launches)
+		hardware := populatedHardware(launches)
+
+		if !populate {
+			fetched, err := service.LookupRockets(ctx, launches)
+			if err != nil {
+				logger.Error("failed to fetch rockets", "error", err)
+			}
+			maps.Copy(rockets, fetched)
 		}
 
 		cost, _ := cmd.Flags().GetBool("cost")
@@ -60,18 +108,43 @@ Available subcommands:
 			return
 		}
 
-		crewMap, err := service.GetCrewMembers(ctx)
-		if err != nil {
-			logger.Error("failed to fetch crew members", "error", err)
-		}
+		if !populate {
+			crewMap, err = service.GetCrewMembers(ctx)
+			if err != nil {
+				logger.Error("failed to fetch crew members", "error", err)
+			}
 
-		launchpads, err := service.GetLaunchpads(ctx)
-		if err != nil {
-			logger.Error("failed to fetch launchpads", "error", err)
+			fetched, err := service.LookupLaunchpads(ctx, launches)
+			if err != nil {
+				logger.Error("failed to fetch launchpads", "error", err)
+			}
+			maps.Copy(launchpads, fetched)
+
+			cores, landpads, payloads := missingHardware(launches, hardware)
+			fetchedCores, err := service.GetCores(ctx, cores...)
+			if err != nil {
+				logger.Error("failed to fetch cores", "error", err)
+			}
+			maps.Copy(hardware.cores, fetchedCores)
+
+			fetchedLandpads, err := service.GetLandpads(ctx, landpads...)
+			if err != nil {
+				logger.Error("failed to fetch landpads", "error", err)
+			}
+			maps.Copy(hardware.landpads, fetchedLandpads)
+
+			fetchedPayloads, err := service.GetPayloads(ctx, payloads...)
+			if err != nil {
+				logger.Error("failed to fetch payloads", "error", err)
+			}
+			maps.Copy(hardware.payloads, fetchedPayloads)
 		}
 
 		fmt.Println(strings.Repeat("-", 80))
 		for _, launch := range launches {
+			// Every launch gets its own deadline for the lookups below, so
+			// enriching a long list is not cut short by one overall timeout.
+			launchCtx, cancelLaunch := context.WithTimeout(context.Background(), launchTimeout)
 
 			status := "❓ Unknown"
 			if launch.Success != nil {
@@ -82,13 +155,17 @@ Available subcommands:
 				}
 			}
 
-			fmt.Printf("📅 %s\n", launch.Date.Format("2006-01-02 15:04"))
+			fmt.Printf("📅 %s\n", launch.DisplayDate())
 			fmt.Printf("   🏷️  %s\n", launch.Name)
 			fmt.Printf("   %s\n", status)
 			fmt.Printf("   🚀 %s\n", rockets[launch.RocketId].Name)
+			if launch.Provider != "" && launch.Provider != model.ProviderSpaceX {
+				fmt.Printf("   🏢 %s (via %s)\n", orUnknown(launch.Agency), launch.Provider)
+			}
 			if launch.Details != "" {
 				fmt.Printf("   ℹ️ %v \n", launch.Details)
 			}
+			printLaunchOutcome(launch, hardware)
 
 			if len(launch.Crew) > 0 && crewMap != nil {
 				fmt.Printf("   👥 Crew: ")
@@ -106,6 +183,7 @@ Available subcommands:
 				launchpad, exists := launchpads[launch.LaunchpadId]
 				if !exists {
 					fmt.Printf("Launchpad not found for launch %s\n", launch.LaunchpadId)
+					cancelLaunch()
 					continue
 				}
 
@@ -113,26 +191,74 @@ Available subcommands:
 				fmt.Printf("      (%s)\n", launchpad.Details)
 
 				if weatherEvents, _ := cmd.Flags().GetBool("weather"); weatherEvents {
-					weatherEvents, err := service.GetEarthEvents(ctx, launchpad.Longitude, launchpad.Latitude, launch.Date)
+					weather, err := service.GetLaunchWeather(launchCtx, launchpad.Latitude, launchpad.Longitude, launch.Date)
 					if err != nil {
-						logger.Error("failed to fetch weather events", "error", err)
-						continue
+						logger.Error("failed to fetch launchpad weather", "error", err)
+						fmt.Printf("   🌡️  Launch day weather unavailable: %s\n", unavailableReason(err))
+					} else if !weather.Available() && weather.Date.Before(model.PowerFirstDay) {
+						fmt.Printf("   🌡️  No launch day weather from NASA POWER before %s\n", model.PowerFirstDay.Format(time.DateOnly))
+					} else if !weather.Available() {
+						fmt.Printf("   🌡️  No launch day weather from NASA POWER for %s yet\n", weather.Date.Format(time.DateOnly))
+					} else {
+						fmt.Printf("   🌡️  %s\n", describeWeather(weather))
 					}
-					if len(weatherEvents) == 0 {
-						fmt.Printf("   🌤️  No warning events found from Nasa for this time & location\n")
+
+					weatherEvents, err := service.GetEarthEvents(launchCtx, launchpad.Longitude, launchpad.Latitude, launch.Date)
+					if err != nil {
+						logger.Error("failed to fetch weather events", "error", err)
+						fmt.Printf("   🌤️  Weather data unavailable: %s\n", unavailableReason(err))
+					} else if len(weatherEvents) == 0 {
+						fmt.Printf("   🌤️  No warning events found from Nasa within %.0f km and %d days of the launch\n", config.HazardRadiusKm, config.HazardWindowDays)
 
 					} else {
-						for _, event := range weatherEvents {
-							fmt.Printf("    🌤️  %s (%s)\n", event.Title, event.Description)
+						for _, nearby := range weatherEvents {
+							fmt.Printf("    🌤️  %s\n", describeEvent(nearby.Event))
+							fmt.Printf("        %.0f km from the pad on %s\n", nearby.DistanceKm, nearby.Date.Format(time.DateOnly))
 						}
 					}
 				}
 			}
 
+			if spaceWeather, _ := cmd.Flags().GetBool("space-weather"); spaceWeather {
+				weather, err := service.GetLaunchSpaceWeather(launchCtx, launch.Date)
+				if err != nil {
+					logger.Error("failed to fetch space weather", "error", err)
+					fmt.Printf("   ☀️  Space weather unavailable: %s\n", unavailableReason(err))
+				} else {
+					fmt.Printf("   ☀️  Within %d days: %s\n", config.SpaceWeatherWindowDays, summarizeSpaceWeather(weather))
+				}
+			}
+
+			apodDir, _ := cmd.Flags().GetString("apod-dir")
+			if showAPOD, _ := cmd.Flags().GetBool("apod"); showAPOD || apodDir != "" {
+				thumbs, _ := cmd.Flags().GetBool("apod-thumbs")
+				apod, ok, err := service.GetLaunchAPOD(launchCtx, launch.Date, thumbs)
+				switch {
+				case err != nil:
+					logger.Error("failed to fetch astronomy picture", "error", err)
+					fmt.Printf("   🔭 Astronomy picture unavailable: %s\n", unavailableReason(err))
+				case !ok:
+					fmt.Printf("   🔭 No astronomy picture for %s yet\n", launch.Date.UTC().Format(time.DateOnly))
+				default:
+					fmt.Printf("   🔭 APOD: %s (%s)\n", apod.Title, apod.URL)
+					if apod.ThumbnailURL != "" {
+						fmt.Printf("   🎞️  thumbnail: %s\n", apod.ThumbnailURL)
+					}
+					if apodDir != "" {
+						hd, _ := cmd.Flags().GetBool("apod-hd")
+						saveAPOD(launchCtx, service, apod, apodDir, hd)
+					}
+				}
+			}
+
 			if asteroids, _ := cmd.Flags().GetBool("asteroids"); asteroids {
-				asteroids, err := service.GetAsteroids(ctx, launch.Date)
+				asteroids, err := service.GetAsteroids(launchCtx, launch.Date)
 				if err != nil {
 					logger.Error("failed to fetch asteroids", "error", err)
+					fmt.Printf("   🌍  Asteroid data unavailable: %s\n", unavailableReason(err))
+					fmt.Println()
+					cancelLaunch()
+					continue
 				}
 				hazardous := 0
 				nonHazardous := 0
@@ -145,19 +271,27 @@ Available subcommands:
 						} else {
 							nonHazardous++
 						}
-						if asteroid.Diameter.Meters.Estimated > maxDiameter {
-							maxDiameter = asteroid.Diameter.Meters.Estimated
+						if asteroid.Diameter.Meters.Max > maxDiameter {
+							maxDiameter = asteroid.Diameter.Meters.Max
 						}
-						if asteroid.Diameter.Meters.Estimated < minDiameter || minDiameter == 0 {
-							minDiameter = asteroid.Diameter.Meters.Estimated
+						if asteroid.Diameter.Meters.Max < minDiameter || minDiameter == 0 {
+							minDiameter = asteroid.Diameter.Meters.Max
 						}
 					}
 				}
 				fmt.Printf("   🌍  total number of near earth asteroids %d (hazardous: %d, non-hazardous: %d) with diameters ranging from %f to %f meters\n", asteroids.ElementCount, hazardous, nonHazardous, minDiameter, maxDiameter)
+				printClosestApproaches(asteroids.ClosestApproaches(launch.Date), closestApproachLimit)
 			}
 
+			cancelLaunch()
 			fmt.Println()
 		}
+
+		if viper.GetBool("verbose") {
+			if quota := service.NASAQuota(); quota.Known {
+				fmt.Printf("🔑 NASA API quota remaining: %d/%d requests this hour\n", quota.Remaining, quota.Limit)
+			}
+		}
 	},
 }
 
@@ -192,60 +326,372 @@ func getCosts(launches []model.Launch, rockets map[string]model.Rocket) (int, er
 	return totalCost, nil
 }
 
-func buildAsteroidsQueryParams(date time.Time) string {
-	return fmt.Sprintf("?start_date=%s&end_date=%s", date.Format("2006-01-02"), date.Format("2006-01-02"))
+// describeEvent summarises an EONET event as its title, categories, status
+// and latest magnitude.
+func describeEvent(event model.NasaEarthEvent) string {
+	details := []string{}
+	for _, category := range event.Categories {
+		details = append(details, category.Title)
+	}
+	if event.IsOpen() {
+		details = append(details, "open")
+	} else {
+		details = append(details, "closed "+event.Closed.Format(time.DateOnly))
+	}
+	for i := len(event.Geometry) - 1; i >= 0; i-- {
+		if geometry := event.Geometry[i]; geometry.MagnitudeValue != nil {
+			details = append(details, fmt.Sprintf("%g %s", *geometry.MagnitudeValue, geometry.MagnitudeUnit))
+			break
+		}
+	}
+
+	description := fmt.Sprintf("%s (%s)", event.Title, strings.Join(details, ", "))
+	if event.Description != "" {
+		description += ": " + event.Description
+	}
+	return description
+}
+
+// describeWeather summarises a day's POWER weather, skipping the values
+// that are not available.
+func describeWeather(weather model.DailyWeather) string {
+	parts := []string{}
+	if weather.Temperature != nil {
+		temperature := fmt.Sprintf("%.1f°C", *weather.Temperature)
+		if weather.MinTemperature != nil && weather.MaxTemperature != nil {
+			temperature += fmt.Sprintf(" (%.1f to %.1f°C)", *weather.MinTemperature, *weather.MaxTemperature)
+		}
+		parts = append(parts, temperature)
+	}
+	if weather.WindSpeed != nil {
+		wind := fmt.Sprintf("wind %.1f m/s", *weather.WindSpeed)
+		if weather.MaxWindSpeed != nil {
+			wind += fmt.Sprintf(" (max %.1f m/s)", *weather.MaxWindSpeed)
+		}
+		parts = append(parts, wind)
+	}
+	if weather.Precipitation != nil {
+		parts = append(parts, fmt.Sprintf("precipitation %.1f mm", *weather.Precipitation))
+	}
+	if weather.CloudCover != nil {
+		parts = append(parts, fmt.Sprintf("cloud cover %.0f%%", *weather.CloudCover))
+	}
+	return fmt.Sprintf("%s: %s", weather.Date.Format(time.DateOnly), strings.Join(parts, ", "))
+}
+
+// unavailableReason explains to the user why optional NASA data is missing.
+func unavailableReason(err error) string {
+	switch {
+	case errors.Is(err, api.ErrCircuitOpen):
+		return "the service is not responding, skipping further requests for now"
+	case errors.Is(err, context.DeadlineExceeded):
+		return "the request timed out"
+	default:
+		return "the request failed"
+	}
+}
+
+// populatedReferences collects the rockets, crew and launchpads embedded in
+// launches fetched with the populate option.
+func populatedReferences(launches []model.Launch) (map[string]model.Rocket, map[string]model.Crew, map[string]model.Launchpad) {
+	rockets := make(map[string]model.Rocket)
+	crew := make(map[string]model.Crew)
+	launchpads := make(map[string]model.Launchpad)
+
+	for _, launch := range launches {
+		if launch.Rocket != nil {
+			rockets[launch.Rocket.ID] = *launch.Rocket
+		}
+		if launch.Launchpad != nil {
+			launchpads[launch.Launchpad.ID] = *launch.Launchpad
+		}
+		for _, member := range launch.CrewMembers {
+			crew[member.ID] = member
+		}
+	}
+	return rockets, crew, launchpads
+}
+
+// referencesEmbedded reports whether every launch carries its rocket and
+// launchpad, so they need not be looked up separately.
+func referencesEmbedded(launches []model.Launch) bool {
+	if len(launches) == 0 {
+		return false
+	}
+	for _, launch := range launches {
+		if launch.Rocket == nil || launch.Launchpad == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// launchHardware holds the cores, landpads and payloads launches refer to,
+// keyed by ID.
+type launchHardware struct {
+	cores    map[string]model.Core
+	landpads map[string]model.Landpad
+	payloads map[string]model.Payload
+}
+
+// populatedHardware collects the cores, landpads and payloads embedded in
+// launches fetched with the populate option.
+func populatedHardware(launches []model.Launch) launchHardware {
+	hardware := launchHardware{
+		cores:    make(map[string]model.Core),
+		landpads: make(map[string]model.Landpad),
+		payloads: make(map[string]model.Payload),
+	}
+	for _, launch := range launches {
+		for _, core := range launch.Cores {
+			if core.Core != nil {
+				hardware.cores[core.Core.ID] = *core.Core
+			}
+			if core.Landpad != nil {
+				hardware.landpads[core.Landpad.ID] = *core.Landpad
+			}
+		}
+		for _, payload := range launch.PayloadDetails {
+			hardware.payloads[payload.ID] = payload
+		}
+	}
+	return hardware
+}
+
+// missingHardware lists the IDs of the cores, landpads and payloads launches
+// refer to that are not in hardware yet, each ID once.
+func missingHardware(launches []model.Launch, hardware launchHardware) (cores, landpads, payloads []string) {
+	for _, launch := range launches {
+		for _, core := range launch.Cores {
+			if _, known := hardware.cores[core.CoreID]; core.CoreID != "" && !known && !slices.Contains(cores, core.CoreID) {
+				cores = append(cores, core.CoreID)
+			}
+			if _, known := hardware.landpads[core.LandpadID]; core.LandpadID != "" && !known && !slices.Contains(landpads, core.LandpadID) {
+				landpads = append(landpads, core.LandpadID)
+			}
+		}
+		for _, id := range launch.Payloads {
+			if _, known := hardware.payloads[id]; !known && !slices.Contains(payloads, id) {
+				payloads = append(payloads, id)
+			}
+		}
+	}
+	return cores, landpads, payloads
+}
+
+// printLaunchOutcome prints why a launch failed, how each core landed, what
+// it carried, and where to watch it.
+func printLaunchOutcome(launch model.Launch, hardware launchHardware) {
+	for _, failure := range launch.Failures {
+		fmt.Printf("   💥 %s\n", describeFailure(failure))
+	}
+	for _, core := range launch.Cores {
+		fmt.Printf("   🔩 %s\n", describeLanding(core, hardware))
+	}
+	if fairings := launch.Fairings; fairings != nil && fairings.RecoveryAttempt != nil && *fairings.RecoveryAttempt {
+		recovered := "recovery attempted"
+		if fairings.Recovered != nil && *fairings.Recovered {
+			recovered = "recovered"
+		} else if fairings.Recovered != nil {
+			recovered = "not recovered"
+		}
+		fmt.Printf("   🪂 Fairings %s\n", recovered)
+	}
+	for _, id := range launch.Payloads {
+		if payload, exists := hardware.payloads[id]; exists {
+			fmt.Printf("   📦 %s\n", describePayload(payload))
+		}
+	}
+	if launch.Window != nil && launch.Upcoming {
+		if *launch.Window == 0 {
+			fmt.Printf("   ⏱️  Instantaneous launch window\n")
+		} else {
+			fmt.Printf("   ⏱️  Launch window %s\n", time.Duration(*launch.Window)*time.Second)
+		}
+	}
+	if launch.Links.Webcast != "" {
+		fmt.Printf("   📺 %s\n", launch.Links.Webcast)
+	}
+	if launch.Links.Wikipedia != "" {
+		fmt.Printf("   📖 %s\n", launch.Links.Wikipedia)
+	}
+}
+
+// describeFailure explains a launch failure, e.g.
+// "T+139s at 40 km: residual stage-1 thrust led to collision". Failures
+// without a time or altitude, as Launch Library 2 reports them, show only
+// the reason.
+func describeFailure(failure model.LaunchFailure) string {
+	if failure.Time == 0 && failure.Altitude == nil && failure.Reason != "" {
+		return failure.Reason
+	}
+	when := fmt.Sprintf("T+%ds", failure.Time)
+	if failure.Altitude != nil {
+		when += fmt.Sprintf(" at %d km", *failure.Altitude)
+	}
+	if failure.Reason == "" {
+		return when
+	}
+	return when + ": " + failure.Reason
+}
+
+// describeLanding names a core and its landing outcome, e.g.
+// "B1049 (flight 5): landed on OCISLY (ASDS)".
+func describeLanding(core model.LaunchCore, hardware launchHardware) string {
+	name := "Unknown core"
+	if known, exists := hardware.cores[core.CoreID]; exists && known.Serial != "" {
+		name = known.Serial
+	}
+	if core.Flight != nil {
+		name += fmt.Sprintf(" (flight %d)", *core.Flight)
+	}
+
+	if core.LandingAttempt == nil || !*core.LandingAttempt {
+		return name + ": no landing attempt"
+	}
+	target := core.LandingType
+	if landpad, exists := hardware.landpads[core.LandpadID]; exists {
+		target = fmt.Sprintf("%s (%s)", landpad.Name, core.LandingType)
+	}
+	if target == "" {
+		target = "unknown site"
+	}
+	switch {
+	case core.LandingSuccess == nil:
+		return fmt.Sprintf("%s: landing on %s", name, target)
+	case *core.LandingSuccess:
+		return fmt.Sprintf("%s: landed on %s", name, target)
+	default:
+		return fmt.Sprintf("%s: failed to land on %s", name, target)
+	}
+}
+
+// describePayload summarises a payload as its name, type, mass, orbit and
+// customers.
+func describePayload(payload model.Payload) string {
+	details := []string{}
+	if payload.Type != "" {
+		details = append(details, payload.Type)
+	}
+	if payload.MassKg != nil {
+		details = append(details, formatMass(payload.MassKg))
+	}
+	if payload.Orbit != "" {
+		details = append(details, payload.Orbit)
+	}
+	if len(payload.Customers) > 0 {
+		details = append(details, strings.Join(payload.Customers, ", "))
+	}
+	if len(details) == 0 {
+		return payload.Name
+	}
+	return fmt.Sprintf("%s (%s)", payload.Name, strings.Join(details, ", "))
 }
 
-func buildLaunchQuery(cmd *cobra.Command) map[string]interface{} {
-	query := map[string]interface{}{
-		"query": map[string]interface{}{},
-		"options": map[string]interface{}{
-			"sort": map[string]interface{}{
-				"date_utc": "desc",
-			},
-		},
+// closestApproachLimit is how many close approaches are listed per launch.
+const closestApproachLimit = 5
+
+// launchTimeout bounds the weather, space weather, APOD and asteroid
+// lookups made for each listed launch.
+const launchTimeout = 30 * time.Second
+
+func printClosestApproaches(approaches []model.AsteroidApproach, limit int) {
+	if len(approaches) == 0 {
+		return
+	}
+	fmt.Printf("      Closest approaches on launch day:\n")
+	for _, approach := range approaches[:min(limit, len(approaches))] {
+		hazard := ""
+		if approach.Asteroid.Hazardous {
+			hazard = " ⚠️  hazardous"
+		}
+		fmt.Printf("      ☄️  %s: %.0f km (%.2f lunar distances) at %.1f km/s, %.0f-%.0f m%s\n",
+			approach.Asteroid.Name,
+			approach.Approach.MissDistance.Kilometers,
+			approach.Approach.MissDistance.Lunar,
+			approach.Approach.RelativeVelocity.KilometersPerSecond,
+			approach.Asteroid.Diameter.Meters.Min,
+			approach.Asteroid.Diameter.Meters.Max,
+			hazard)
 	}
+}
+
+func buildLaunchQuery(cmd *cobra.Command) (*api.LaunchQuery, error) {
+	query := api.NewLaunchQuery().SortBy("date_utc", api.Descending)
 
 	startDate, _ := cmd.Flags().GetString("start")
 	endDate, _ := cmd.Flags().GetString("end")
 
 	if startDate != "" && endDate != "" {
-		dateQuery := map[string]interface{}{
-			"$gte": startDate + "T00:00:00.000Z",
-			"$lte": endDate + "T23:59:59.999Z",
+		start, err := time.Parse(time.DateOnly, startDate)
+		if err != nil {
+			return nil, fmt.Errorf("invalid start date %q: %w", startDate, err)
+		}
+		end, err := time.Parse(time.DateOnly, endDate)
+		if err != nil {
+			return nil, fmt.Errorf("invalid end date %q: %w", endDate, err)
 		}
-		query["query"].(map[string]interface{})["date_utc"] = dateQuery
+		query.Between(start, end.Add(24*time.Hour-time.Millisecond))
 	}
 
 	failed, _ := cmd.Flags().GetBool("failed")
 	if failed {
-		query["query"].(map[string]interface{})["success"] = false
+		query.Success(false)
 	}
 
 	upcoming, _ := cmd.Flags().GetBool("upcoming")
-	if upcoming {
-		query["query"].(map[string]interface{})["upcoming"] = true
-	} else {
-		query["query"].(map[string]interface{})["upcoming"] = false
-	}
+	query.Upcoming(upcoming)
 
 	limit, _ := cmd.Flags().GetInt("limit")
 	if limit > 0 {
-		query["options"].(map[string]interface{})["limit"] = limit
+		query.Limit(limit)
+	}
+
+	page, _ := cmd.Flags().GetInt("page")
+	if page > 0 {
+		query.Page(page)
+	}
+
+	offset, _ := cmd.Flags().GetInt("offset")
+	if offset > 0 {
+		query.Offset(offset)
+	}
+
+	populate, _ := cmd.Flags().GetBool("populate")
+	if populate {
+		query.Populate("rocket", "launchpad", "crew", "payloads", "cores.core", "cores.landpad")
 	}
-	return query
+	return query, nil
 }
 
 func init() {
 	rootCmd.AddCommand(launchesCmd)
 
-	launchesCmd.Flags().IntP("limit", "l", 200, "Number of past launches to show")
+	launchesCmd.Flags().IntP("limit", "l", 200, "Number of past launches to show (page size with --all)")
+	launchesCmd.Flags().Int("page", 0, "Page of results to show, starting at 1")
+	launchesCmd.Flags().Int("offset", 0, "Number of matching launches to skip")
+	launchesCmd.Flags().Bool("all", false, "Fetch every matching launch across all pages")
+	launchesCmd.Flags().Bool("populate", false, "Resolve rockets, launchpads, crew, cores and payloads server-side in a single request")
 	launchesCmd.Flags().StringP("start", "s", "", "Start date (YYYY-MM-DD)")
 	launchesCmd.Flags().StringP("end", "e", "", "End date (YYYY-MM-DD)")
 	launchesCmd.Flags().BoolP("failed", "f", false, "Filter for failed launches only")
 	launchesCmd.Flags().BoolP("upcoming", "u", false, "Filter for upcoming launches only")
 	launchesCmd.Flags().BoolP("cost", "c", false, "Get the total cost for all matching launches")
 	launchesCmd.Flags().BoolP("launchpad", "p", false, "Show launchpad information")
-	launchesCmd.Flags().BoolP("weather", "w", false, "Show launchpad location weather warning information")
+	launchesCmd.Flags().BoolP("weather", "w", false, "Show launch day weather and natural event warnings at the launchpad")
 	launchesCmd.Flags().BoolP("asteroids", "a", false, "Show near Earth orbiting asteroid information")
+	launchesCmd.Flags().Bool("space-weather", false, "Show solar flares, CMEs, geomagnetic storms and SEP events around each launch")
+	launchesCmd.Flags().Int("space-weather-days", model.DefaultSpaceWeatherWindowDays, "Report space weather up to this many days either side of the launch")
+	launchesCmd.Flags().Bool("apod", false, "Show the Astronomy Picture of the Day for each launch date")
+	launchesCmd.Flags().String("apod-dir", "", "Download the Astronomy Picture of the Day for each launch date to this directory")
+	launchesCmd.Flags().Bool("apod-hd", false, "Download high resolution images with --apod-dir when available")
+	launchesCmd.Flags().Bool("apod-thumbs", false, "Include thumbnail URLs for APOD videos")
+	launchesCmd.Flags().Float64("hazard-radius", model.DefaultHazardRadiusKm, "Report natural events within this many km of the launchpad")
+	launchesCmd.Flags().Int("hazard-days", model.DefaultHazardWindowDays, "Report natural events up to this many days either side of the launch")
+
+	viper.BindPFlag("hazard_radius_km", launchesCmd.Flags().Lookup("hazard-radius"))
+	viper.BindPFlag("hazard_window_days", launchesCmd.Flags().Lookup("hazard-days"))
+	// space_weather_days is also set by spaceweather --days, so each command
+	// binds its own flag in PreRun.
+	viper.SetDefault("space_weather_days", model.DefaultSpaceWeatherWindowDays)
 }
@@ -1,11 +1,14 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
 	"testing"
 
+	"github.com/MitiaRD/ReMarkable-cli/model"
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestBuildLaunchQuery(t *testing.T) {
@@ -157,6 +160,42 @@ func TestBuildLaunchQuery(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "with page and offset",
+			flags: map[string]interface{}{
+				"page":   3,
+				"offset": 20,
+			},
+			expected: map[string]interface{}{
+				"query": map[string]interface{}{
+					"upcoming": false,
+				},
+				"options": map[string]interface{}{
+					"sort": map[string]interface{}{
+						"date_utc": "desc",
+					},
+					"page":   3,
+					"offset": 20,
+				},
+			},
+		},
+		{
+			name: "with populate",
+			flags: map[string]interface{}{
+				"populate": true,
+			},
+			expected: map[string]interface{}{
+				"query": map[string]interface{}{
+					"upcoming": false,
+				},
+				"options": map[string]interface{}{
+					"sort": map[string]interface{}{
+						"date_utc": "desc",
+					},
+					"populate": []string{"rocket", "launchpad", "crew", "payloads", "cores.core", "cores.landpad"},
+				},
+			},
+		},
 		{
 			name: "with only start date (should not add date query)",
 			flags: map[string]interface{}{
@@ -200,6 +239,9 @@ func TestBuildLaunchQuery(t *testing.T) {
 			cmd.Flags().Bool("failed", false, "Show failed launches")
 			cmd.Flags().Bool("upcoming", false, "Show upcoming launches")
 			cmd.Flags().Int("limit", 0, "Limit number of results")
+			cmd.Flags().Int("page", 0, "Page of results")
+			cmd.Flags().Int("offset", 0, "Results to skip")
+			cmd.Flags().Bool("populate", false, "Populate references")
 
 			for flag, value := range tt.flags {
 				switch v := value.(type) {
@@ -214,9 +256,97 @@ func TestBuildLaunchQuery(t *testing.T) {
 				}
 			}
 
-			result := buildLaunchQuery(cmd)
+			result, err := buildLaunchQuery(cmd)
+			require.NoError(t, err)
 
-			assert.Equal(t, tt.expected, result, "Query should match expected structure")
+			expected, err := json.Marshal(tt.expected)
+			require.NoError(t, err)
+			actual, err := json.Marshal(result)
+			require.NoError(t, err)
+
+			assert.JSONEq(t, string(expected), string(actual), "Query should match expected structure")
 		})
 	}
 }
+
+func TestBuildLaunchQueryRejectsInvalidDates(t *testing.T) {
+	cmd := &cobra.Command{}
+	cmd.Flags().String("start", "", "Start date")
+	cmd.Flags().String("end", "", "End date")
+	cmd.Flags().Set("start", "2024-13-01")
+	cmd.Flags().Set("end", "2024-12-31")
+
+	_, err := buildLaunchQuery(cmd)
+	assert.Error(t, err)
+}
+
+func TestDescribeLanding(t *testing.T) {
+	yes, no := true, false
+	flight := 5
+	hardware := launchHardware{
+		cores:    map[string]model.Core{"b1049": {ID: "b1049", Serial: "B1049"}},
+		landpads: map[string]model.Landpad{"ocisly": {ID: "ocisly", Name: "OCISLY"}},
+	}
+
+	tests := []struct {
+		name     string
+		core     model.LaunchCore
+		expected string
+	}{
+		{
+			name:     "landed on a known landpad",
+			core:     model.LaunchCore{CoreID: "b1049", Flight: &flight, LandingAttempt: &yes, LandingSuccess: &yes, LandingType: "ASDS", LandpadID: "ocisly"},
+			expected: "B1049 (flight 5): landed on OCISLY (ASDS)",
+		},
+		{
+			name:     "failed landing",
+			core:     model.LaunchCore{CoreID: "b1049", LandingAttempt: &yes, LandingSuccess: &no, LandingType: "Ocean"},
+			expected: "B1049: failed to land on Ocean",
+		},
+		{
+			name:     "landing still to come",
+			core:     model.LaunchCore{CoreID: "b1049", LandingAttempt: &yes, LandingType: "ASDS", LandpadID: "ocisly"},
+			expected: "B1049: landing on OCISLY (ASDS)",
+		},
+		{
+			name:     "expended unknown core",
+			core:     model.LaunchCore{LandingAttempt: &no},
+			expected: "Unknown core: no landing attempt",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, describeLanding(tt.core, hardware))
+		})
+	}
+}
+
+func TestDescribeFailureAndPayload(t *testing.T) {
+	altitude := 40
+	assert.Equal(t, "T+139s at 40 km: residual stage 1 thrust led to collision",
+		describeFailure(model.LaunchFailure{Time: 139, Altitude: &altitude, Reason: "residual stage 1 thrust led to collision"}))
+	assert.Equal(t, "T+33s: merlin engine failure", describeFailure(model.LaunchFailure{Time: 33, Reason: "merlin engine failure"}))
+
+	mass := 3136.0
+	assert.Equal(t, "Thaicom 8 (Satellite, 3136 kg, GTO, Thaicom)",
+		describePayload(model.Payload{Name: "Thaicom 8", Type: "Satellite", MassKg: &mass, Orbit: "GTO", Customers: []string{"Thaicom"}}))
+	assert.Equal(t, "Mystery", describePayload(model.Payload{Name: "Mystery"}))
+}
+
+func TestMissingHardware(t *testing.T) {
+	launches := []model.Launch{
+		{Cores: []model.LaunchCore{{CoreID: "c-1", LandpadID: "ocisly"}}, Payloads: []string{"p-1", "p-2"}},
+		{Cores: []model.LaunchCore{{CoreID: "c-1", LandpadID: "jrti"}, {CoreID: "c-2"}}, Payloads: []string{"p-2"}},
+	}
+	hardware := launchHardware{
+		cores:    map[string]model.Core{"c-2": {ID: "c-2"}},
+		landpads: map[string]model.Landpad{},
+		payloads: map[string]model.Payload{"p-1": {ID: "p-1"}},
+	}
+
+	cores, landpads, payloads := missingHardware(launches, hardware)
+	assert.Equal(t, []string{"c-1"}, cores)
+	assert.Equal(t, []string{"ocisly", "jrti"}, landpads)
+	assert.Equal(t, []string{"p-2"}, payloads)
+}
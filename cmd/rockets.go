@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/MitiaRD/ReMarkable-cli/model"
+	"github.com/spf13/cobra"
+)
+
+var rocketsCmd = &cobra.Command{
+	Use:   "rockets",
+	Short: "List the rockets of the selected launch providers",
+	Long: `Rockets lists the rockets known to the launch providers selected with
+--provider.
+
+Available subcommands:
+  search       - Only show rockets whose name contains this text`,
+	Run: referenceList("rockets", (*LaunchesService).GetRockets, func(r model.Rocket) string { return r.Name }, printRocket),
+}
+
+var padsCmd = &cobra.Command{
+	Use:   "pads",
+	Short: "List the launchpads of the selected launch providers",
+	Long: `Pads lists the launchpads known to the launch providers selected with
+--provider.
+
+Available subcommands:
+  search       - Only show pads whose name or locality contains this text`,
+	Run: referenceList("launchpads", (*LaunchesService).GetLaunchpads, func(p model.Launchpad) string { return p.Name + " " + p.Locality }, printLaunchpad),
+}
+
+// referenceList builds the Run function of a command listing every rocket or
+// launchpad, sorted by name and filtered by the --search flag.
+func referenceList[T any](name string, fetch func(*LaunchesService, context.Context) (map[string]T, error), label func(T) string, print func(T)) func(*cobra.Command, []string) {
+	return func(cmd *cobra.Command, args []string) {
+		ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+		defer cancel()
+
+		config, err := LoadConfiguration()
+		if err != nil {
+			fmt.Printf("Error loading configuration: %v\n", err)
+			return
+		}
+
+		logger := SetupLogger()
+		service, err := NewLaunchesService(config, logger)
+		if err != nil {
+			fmt.Printf("Error setting up API clients: %v\n", err)
+			return
+		}
+
+		docs, err := fetch(service, ctx)
+		if err != nil {
+			logger.Error("failed to fetch "+name, "error", err)
+			fmt.Printf("Error fetching %s: %v\n", name, err)
+			return
+		}
+
+		search, _ := cmd.Flags().GetString("search")
+		matches := []T{}
+		for _, doc := range slices.SortedFunc(maps.Values(docs), func(a, b T) int { return strings.Compare(label(a), label(b)) }) {
+			if strings.Contains(strings.ToLower(label(doc)), strings.ToLower(search)) {
+				matches = append(matches, doc)
+			}
+		}
+
+		fmt.Printf("\n%s (showing %d):\n", strings.ToUpper(name[:1])+name[1:], len(matches))
+		fmt.Println(strings.Repeat("-", 80))
+		for _, doc := range matches {
+			print(doc)
+			fmt.Println()
+		}
+	}
+}
+
+func printRocket(rocket model.Rocket) {
+	fmt.Printf("🚀 %s\n", rocket.Name)
+	if rocket.Company != "" {
+		fmt.Printf("   %s, %s\n", rocket.Company, orUnknown(rocket.Country))
+	}
+	details := []string{}
+	if rocket.FirstFlight != "" {
+		details = append(details, "first flight "+rocket.FirstFlight)
+	}
+	if rocket.SuccessRate > 0 {
+		details = append(details, fmt.Sprintf("%d%% success rate", rocket.SuccessRate))
+	}
+	if rocket.CostPerLaunch > 0 {
+		details = append(details, fmt.Sprintf("$%d per launch", rocket.CostPerLaunch))
+	}
+	if rocket.Height.Meters > 0 {
+		details = append(details, fmt.Sprintf("%.1f m tall", rocket.Height.Meters))
+	}
+	if len(details) > 0 {
+		fmt.Printf("   %s\n", strings.Join(details, ", "))
+	}
+	if rocket.Description != "" {
+		fmt.Printf("   ℹ️ %s\n", rocket.Description)
+	}
+}
+
+func printLaunchpad(launchpad model.Launchpad) {
+	fmt.Printf("📍 %s\n", launchpad.Name)
+	fmt.Printf("   %s (%.4f, %.4f)\n", orUnknown(launchpad.Locality), launchpad.Latitude, launchpad.Longitude)
+	if launchpad.Details != "" {
+		fmt.Printf("   ℹ️ %s\n", launchpad.Details)
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(rocketsCmd, padsCmd)
+
+	rocketsCmd.Flags().StringP("search", "q", "", "Only show rockets whose name contains this text")
+	padsCmd.Flags().StringP("search", "q", "", "Only show pads whose name or locality contains this text")
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/MitiaRD/ReMarkable-cli/model"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"github.com/subosito/gotenv"
@@ -21,7 +22,8 @@ var rootCmd = &cobra.Command{
 	Long: `A comprehensive CLI tool for exploring space launch information.
 	
 This tool integrates with space launch APIs to provide detailed information about:
-• Upcoming and historical launches
+• Upcoming and historical launches by SpaceX and, through Launch Library 2,
+  every other launch provider
 • Launch statistics and success rates
 • Cost analysis and organization data
 • Crew information and mission details
@@ -54,6 +56,30 @@ func init() {
 	// will be global for your application.
 
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.ReMarkable-cli.yaml)")
+	rootCmd.PersistentFlags().BoolP("verbose", "v", false, "Verbose output, including debug logs and API quota")
+	rootCmd.PersistentFlags().String("spacex-url", "", "SpaceX API base URL (default "+model.DefaultSpaceXBaseURL+")")
+	rootCmd.PersistentFlags().String("nasa-url", "", "NASA API base URL (default "+model.DefaultNASABaseURL+")")
+	rootCmd.PersistentFlags().String("eonet-url", "", "NASA EONET API base URL (default "+model.DefaultEONETBaseURL+")")
+	rootCmd.PersistentFlags().String("power-url", "", "NASA POWER API base URL (default "+model.DefaultPOWERBaseURL+")")
+	rootCmd.PersistentFlags().String("ll2-url", "", "Launch Library 2 API base URL (default "+model.DefaultLL2BaseURL+")")
+	rootCmd.PersistentFlags().String("provider", "", "Launch providers: spacex, ll2, all or a comma-separated list of provider names (default spacex)")
+
+	rootCmd.PersistentFlags().Bool("no-cache", false, "Bypass the on-disk response cache")
+	rootCmd.PersistentFlags().Bool("refresh", false, "Ignore cached responses and refetch them")
+	rootCmd.PersistentFlags().String("record", "", "Record all API traffic as fixtures in this directory")
+	rootCmd.PersistentFlags().String("replay", "", "Serve API traffic from fixtures in this directory instead of the network")
+
+	viper.BindPFlag("verbose", rootCmd.PersistentFlags().Lookup("verbose"))
+	viper.BindPFlag("spacex_base_url", rootCmd.PersistentFlags().Lookup("spacex-url"))
+	viper.BindPFlag("nasa_base_url", rootCmd.PersistentFlags().Lookup("nasa-url"))
+	viper.BindPFlag("eonet_base_url", rootCmd.PersistentFlags().Lookup("eonet-url"))
+	viper.BindPFlag("power_base_url", rootCmd.PersistentFlags().Lookup("power-url"))
+	viper.BindPFlag("ll2_base_url", rootCmd.PersistentFlags().Lookup("ll2-url"))
+	viper.BindPFlag("provider", rootCmd.PersistentFlags().Lookup("provider"))
+	viper.BindPFlag("no_cache", rootCmd.PersistentFlags().Lookup("no-cache"))
+	viper.BindPFlag("refresh", rootCmd.PersistentFlags().Lookup("refresh"))
+	viper.BindPFlag("record", rootCmd.PersistentFlags().Lookup("record"))
+	viper.BindPFlag("replay", rootCmd.PersistentFlags().Lookup("replay"))
 
 	// Cobra also supports local flags, which will only run
 	// when this action is called directly.
@@ -1,76 +1,853 @@
 package cmd
 
 import (
+	"cmp"
 	"context"
+	"fmt"
 	"log/slog"
+	"maps"
 	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/MitiaRD/ReMarkable-cli/api"
 	"github.com/MitiaRD/ReMarkable-cli/model"
+	"github.com/spf13/viper"
 )
 
 type LaunchesService struct {
-	spaceXClient *api.SpaceXClient
-	nasaClient   *api.NASAClient
-	logger       *slog.Logger
-	config       *model.Config
+	spaceXClient        api.SpaceXAPI
+	nasaClient          api.NASAAPI
+	donkiClient         api.DONKIAPI
+	launchLibraryClient api.LaunchLibraryAPI
+	// providers serve launches, rockets and launchpads, in order of
+	// preference when the same launch comes from several of them.
+	providers []api.LaunchProvider
+	logger    *slog.Logger
+	config    *model.Config
 }
 
-func NewLaunchesService(config *model.Config, logger *slog.Logger) *LaunchesService {
-	return &LaunchesService{
-		spaceXClient: api.NewSpaceXClient(config, logger),
-		nasaClient:   api.NewNASAClient(config, logger),
-		logger:       logger,
-		config:       config,
+// Clients are the API clients a LaunchesService is built on.
+type Clients struct {
+	SpaceX        api.SpaceXAPI
+	NASA          api.NASAAPI
+	DONKI         api.DONKIAPI
+	LaunchLibrary api.LaunchLibraryAPI
+	// Providers are the other launch providers, by name.
+	Providers map[string]api.LaunchProvider
+}
+
+// NewLaunchesServiceWithClients builds a service on top of the given
+// clients, e.g. the in-memory fakes from the apitest package. Launches come
+// from the providers selected by config.Provider, in that order.
+func NewLaunchesServiceWithClients(clients Clients, config *model.Config, logger *slog.Logger) *LaunchesService {
+	service := &LaunchesService{
+		spaceXClient:        clients.SpaceX,
+		nasaClient:          clients.NASA,
+		donkiClient:         clients.DONKI,
+		launchLibraryClient: clients.LaunchLibrary,
+		logger:              logger,
+		config:              config,
+	}
+
+	for _, name := range config.ProviderNames() {
+		switch {
+		case name == model.ProviderSpaceX && clients.SpaceX != nil:
+			service.providers = append(service.providers, api.NewSpaceXProvider(clients.SpaceX))
+		case name == model.ProviderLaunchLibrary && clients.LaunchLibrary != nil:
+			service.providers = append(service.providers, clients.LaunchLibrary)
+		case clients.Providers[name] != nil:
+			service.providers = append(service.providers, clients.Providers[name])
+		default:
+			logger.Warn("launch provider unavailable", "provider", name)
+		}
+	}
+	return service
+}
+
+// NewLaunchesService builds a service on the real API clients. It fails
+// when record or replay mode was asked for but cannot be set up, rather than
+// quietly falling back to the network.
+func NewLaunchesService(config *model.Config, logger *slog.Logger) (*LaunchesService, error) {
+	opts := []api.RequesterOption{
+		api.WithBreakers(api.NewBreakers(config.BreakerThreshold, config.BreakerCooldown)),
+	}
+
+	switch {
+	case config.ReplayDir != "":
+		replay, err := api.NewReplayTransport(config.ReplayDir)
+		if err != nil {
+			return nil, fmt.Errorf("replay mode unavailable: %w", err)
+		}
+		opts = append(opts, api.WithTransport(replay))
+	case config.RecordDir != "":
+		recorder, err := api.NewRecordingTransport(config.RecordDir, nil, config.NASAAPIKey, config.LL2APIToken)
+		if err != nil {
+			return nil, fmt.Errorf("record mode unavailable: %w", err)
+		}
+		opts = append(opts, api.WithTransport(recorder))
+	}
+
+	// Recorded and replayed runs skip the cache: a cache hit would never be
+	// recorded, and a revalidation would record a bodyless 304.
+	if !config.NoCache && config.ReplayDir == "" && config.RecordDir == "" {
+		cache, err := OpenCache(config)
+		if err != nil {
+			logger.Warn("response cache disabled", "error", err)
+		} else {
+			opts = append(opts, api.WithCache(cache, config.RefreshCache))
+		}
+	}
+
+	nasa := api.NewNASAClient(config, logger, opts...)
+	return NewLaunchesServiceWithClients(Clients{
+		SpaceX: api.NewSpaceXClient(config, logger, opts...),
+		NASA:   nasa,
+		DONKI:  api.NewDONKIClient(nasa),
+
+		LaunchLibrary: api.NewLaunchLibraryClient(config, logger, opts...),
+		Providers:     newProviders(config, logger, opts),
+	}, config, logger), nil
+}
+
+// newProviders builds the selected providers other than the built-in ones
+// from the provider registry. Providers that fail to build are logged and
+// left out.
+func newProviders(config *model.Config, logger *slog.Logger, opts []api.RequesterOption) map[string]api.LaunchProvider {
+	providers := make(map[string]api.LaunchProvider)
+	for _, name := range config.ProviderNames() {
+		if name == model.ProviderSpaceX || name == model.ProviderLaunchLibrary {
+			continue
+		}
+		provider, err := api.NewProvider(api.ProviderOptions{
+			Name:             name,
+			Settings:         config.Providers[name],
+			Config:           config,
+			Logger:           logger,
+			RequesterOptions: opts,
+		})
+		if err != nil {
+			logger.Error("launch provider unavailable", "provider", name, "error", err)
+			continue
+		}
+		providers[name] = provider
+	}
+	return providers
+}
+
+func (s *LaunchesService) GetLaunches(ctx context.Context, query *api.LaunchQuery) ([]model.Launch, error) {
+	page, err := s.QueryLaunches(ctx, query)
+	return page.Docs, err
+}
+
+// QueryLaunches returns a page of launches from every provider. With several
+// providers, page n holds page n of each provider with duplicate launches
+// removed, and the totals add up the providers' totals.
+func (s *LaunchesService) QueryLaunches(ctx context.Context, query *api.LaunchQuery) (api.Page[model.Launch], error) {
+	pages, err := fromProviders(ctx, s, s.providers, "launches", func(provider api.LaunchProvider, ctx context.Context) (api.Page[model.Launch], error) {
+		page, err := provider.QueryLaunches(ctx, query)
+		stampProvider(page.Docs, provider.Name())
+		return page, err
+	})
+	if err != nil {
+		return api.Page[model.Launch]{}, err
+	}
+	if len(pages) == 1 {
+		return pages[0], nil
+	}
+
+	var merged api.Page[model.Launch]
+	sources := [][]model.Launch{}
+	for _, page := range pages {
+		sources = append(sources, page.Docs)
+		merged.TotalDocs += page.TotalDocs - len(page.Docs)
+		merged.Limit = max(merged.Limit, page.Limit)
+		merged.Page = max(merged.Page, page.Page)
+		merged.TotalPages = max(merged.TotalPages, page.TotalPages)
+		merged.HasPrevPage = merged.HasPrevPage || page.HasPrevPage
+		merged.HasNextPage = merged.HasNextPage || page.HasNextPage
+	}
+	merged.Docs = api.MergeLaunches(query.Options().Sort, sources...)
+	merged.TotalDocs += len(merged.Docs)
+	if merged.HasPrevPage {
+		prev := merged.Page - 1
+		merged.PrevPage = &prev
+	}
+	if merged.HasNextPage {
+		next := merged.Page + 1
+		merged.NextPage = &next
 	}
+	return merged, nil
 }
 
-func (s *LaunchesService) GetLaunches(ctx context.Context, query map[string]interface{}) ([]model.Launch, error) {
-	return s.spaceXClient.GetLaunchesWithQuery(ctx, query)
+// GetAllLaunches follows pagination until every launch matching query has
+// been fetched from every provider.
+func (s *LaunchesService) GetAllLaunches(ctx context.Context, query *api.LaunchQuery) ([]model.Launch, error) {
+	sources, err := fromProviders(ctx, s, s.providers, "launches", func(provider api.LaunchProvider, ctx context.Context) ([]model.Launch, error) {
+		var launches []model.Launch
+		for launch, err := range api.IterateLaunches(ctx, provider, query) {
+			if err != nil {
+				return launches, err
+			}
+			launches = append(launches, launch)
+		}
+		stampProvider(launches, provider.Name())
+		return launches, nil
+	})
+	if len(sources) == 1 {
+		return sources[0], err
+	}
+	return api.MergeLaunches(query.Options().Sort, sources...), err
 }
 
+// GetRockets returns the rockets of every provider.
 func (s *LaunchesService) GetRockets(ctx context.Context) (map[string]model.Rocket, error) {
-	return s.spaceXClient.GetAllRockets(ctx)
+	sources, err := fromProviders(ctx, s, s.providers, "rockets", api.LaunchProvider.GetRockets)
+	return mergeMaps(sources), err
 }
 
 func (s *LaunchesService) GetCrewMembers(ctx context.Context) (map[string]model.Crew, error) {
-	return s.spaceXClient.GetAllCrewMembers(ctx)
+	spaceX, err := s.spaceX()
+	if err != nil {
+		return nil, err
+	}
+	return spaceX.GetAllCrewMembers(ctx)
 }
 
+// GetLaunchpads returns the launchpads of every provider.
 func (s *LaunchesService) GetLaunchpads(ctx context.Context) (map[string]model.Launchpad, error) {
-	return s.spaceXClient.GetAllLaunchpads(ctx)
+	sources, err := fromProviders(ctx, s, s.providers, "launchpads", api.LaunchProvider.GetLaunchpads)
+	return mergeMaps(sources), err
+}
+
+// spaceX returns the SpaceX client, which services built on other providers
+// may not have.
+func (s *LaunchesService) spaceX() (api.SpaceXAPI, error) {
+	if s.spaceXClient == nil {
+		return nil, fmt.Errorf("no SpaceX client configured")
+	}
+	return s.spaceXClient, nil
+}
+
+// QueryAgencies lists Launch Library 2 agencies.
+func (s *LaunchesService) QueryAgencies(ctx context.Context, query *api.LibraryQuery) (api.Page[model.Agency], error) {
+	if s.launchLibraryClient == nil {
+		return api.Page[model.Agency]{}, fmt.Errorf("no Launch Library client configured")
+	}
+	return s.launchLibraryClient.QueryAgencies(ctx, query)
+}
+
+// LookupRockets returns the rockets launches refer to but do not embed. Only
+// the providers of such launches are asked, so Launch Library 2, whose
+// launches always embed their rocket, never has its launcher list walked.
+func (s *LaunchesService) LookupRockets(ctx context.Context, launches []model.Launch) (map[string]model.Rocket, error) {
+	providers := s.providersOf(launches, func(launch model.Launch) bool {
+		return launch.Rocket == nil && launch.RocketId != ""
+	})
+	if len(providers) == 0 {
+		return map[string]model.Rocket{}, nil
+	}
+	sources, err := fromProviders(ctx, s, providers, "rockets", api.LaunchProvider.GetRockets)
+	return mergeMaps(sources), err
+}
+
+// LookupLaunchpads returns the launchpads launches refer to but do not
+// embed, asking only the providers of such launches.
+func (s *LaunchesService) LookupLaunchpads(ctx context.Context, launches []model.Launch) (map[string]model.Launchpad, error) {
+	providers := s.providersOf(launches, func(launch model.Launch) bool {
+		return launch.Launchpad == nil && launch.LaunchpadId != ""
+	})
+	if len(providers) == 0 {
+		return map[string]model.Launchpad{}, nil
+	}
+	sources, err := fromProviders(ctx, s, providers, "launchpads", api.LaunchProvider.GetLaunchpads)
+	return mergeMaps(sources), err
+}
+
+// providersOf returns the providers, in order of preference, of the launches
+// for which need is true.
+func (s *LaunchesService) providersOf(launches []model.Launch, need func(model.Launch) bool) []api.LaunchProvider {
+	var providers []api.LaunchProvider
+	for _, provider := range s.providers {
+		if slices.ContainsFunc(launches, func(launch model.Launch) bool {
+			return launch.Provider == provider.Name() && need(launch)
+		}) {
+			providers = append(providers, provider)
+		}
+	}
+	return providers
+}
+
+// fromProviders calls fetch on every one of providers concurrently and
+// returns the successful results in provider order. A provider that fails is
+// logged and skipped; the error is only returned when no provider succeeded.
+func fromProviders[T any](ctx context.Context, s *LaunchesService, providers []api.LaunchProvider, what string, fetch func(api.LaunchProvider, context.Context) (T, error)) ([]T, error) {
+	if len(providers) == 0 {
+		return nil, fmt.Errorf("no launch provider configured for %q", s.config.Provider)
+	}
+
+	results := make([]T, len(providers))
+	errs := make([]error, len(providers))
+	var wg sync.WaitGroup
+	for i, provider := range providers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], errs[i] = fetch(provider, ctx)
+		}()
+	}
+	wg.Wait()
+
+	succeeded := []T{}
+	for i, err := range errs {
+		if err != nil {
+			s.logger.Warn("launch provider failed", "provider", providers[i].Name(), "fetching", what, "error", err)
+			continue
+		}
+		succeeded = append(succeeded, results[i])
+	}
+	if len(succeeded) == 0 {
+		return nil, errs[0]
+	}
+	return succeeded, nil
+}
+
+// stampProvider records which provider launches came from unless the
+// provider already did.
+func stampProvider(launches []model.Launch, provider string) {
+	for i := range launches {
+		if launches[i].Provider == "" {
+			launches[i].Provider = provider
+		}
+	}
+}
+
+// mergeMaps combines maps keyed by ID; earlier maps win on conflicts.
+func mergeMaps[T any](sources []map[string]T) map[string]T {
+	merged := make(map[string]T)
+	for i := len(sources) - 1; i >= 0; i-- {
+		maps.Copy(merged, sources[i])
+	}
+	return merged
+}
+
+// GetPayloads looks up the payloads with the given IDs in one query rather
+// than fetching the whole collection. No IDs means no request.
+func (s *LaunchesService) GetPayloads(ctx context.Context, ids ...string) (map[string]model.Payload, error) {
+	if len(ids) == 0 {
+		return map[string]model.Payload{}, nil
+	}
+	spaceX, err := s.spaceX()
+	if err != nil {
+		return nil, err
+	}
+	return getByID(ctx, ids, spaceX.QueryPayloads, func(p model.Payload) string { return p.ID })
+}
+
+// GetCores looks up the cores with the given IDs.
+func (s *LaunchesService) GetCores(ctx context.Context, ids ...string) (map[string]model.Core, error) {
+	if len(ids) == 0 {
+		return map[string]model.Core{}, nil
+	}
+	spaceX, err := s.spaceX()
+	if err != nil {
+		return nil, err
+	}
+	return getByID(ctx, ids, spaceX.QueryCores, func(c model.Core) string { return c.ID })
+}
+
+// GetLandpads looks up the landpads with the given IDs.
+func (s *LaunchesService) GetLandpads(ctx context.Context, ids ...string) (map[string]model.Landpad, error) {
+	if len(ids) == 0 {
+		return map[string]model.Landpad{}, nil
+	}
+	spaceX, err := s.spaceX()
+	if err != nil {
+		return nil, err
+	}
+	return getByID(ctx, ids, spaceX.QueryLandpads, func(l model.Landpad) string { return l.ID })
+}
+
+// getByID fetches the documents with the given IDs through an _id $in
+// query. Callers skip it when there are no IDs.
+func getByID[T any](ctx context.Context, ids []string, fetchPage func(context.Context, *api.CollectionQuery) (api.Page[T], error), id func(T) string) (map[string]T, error) {
+	docs := make(map[string]T)
+	query := api.NewCollectionQuery().In("_id", ids...).Limit(len(ids))
+	for doc, err := range api.IterateCollection(ctx, query, fetchPage) {
+		if err != nil {
+			return docs, err
+		}
+		docs[id(doc)] = doc
+	}
+	return docs, nil
+}
+
+func (s *LaunchesService) QueryPayloads(ctx context.Context, query *api.CollectionQuery) (api.Page[model.Payload], error) {
+	spaceX, err := s.spaceX()
+	if err != nil {
+		return api.Page[model.Payload]{}, err
+	}
+	return spaceX.QueryPayloads(ctx, query)
+}
+
+func (s *LaunchesService) GetPayload(ctx context.Context, id string) (model.Payload, error) {
+	spaceX, err := s.spaceX()
+	if err != nil {
+		return model.Payload{}, err
+	}
+	return spaceX.GetPayload(ctx, id)
+}
+
+func (s *LaunchesService) QueryCores(ctx context.Context, query *api.CollectionQuery) (api.Page[model.Core], error) {
+	spaceX, err := s.spaceX()
+	if err != nil {
+		return api.Page[model.Core]{}, err
+	}
+	return spaceX.QueryCores(ctx, query)
+}
+
+func (s *LaunchesService) GetCore(ctx context.Context, id string) (model.Core, error) {
+	spaceX, err := s.spaceX()
+	if err != nil {
+		return model.Core{}, err
+	}
+	return spaceX.GetCore(ctx, id)
+}
+
+func (s *LaunchesService) QueryCapsules(ctx context.Context, query *api.CollectionQuery) (api.Page[model.Capsule], error) {
+	spaceX, err := s.spaceX()
+	if err != nil {
+		return api.Page[model.Capsule]{}, err
+	}
+	return spaceX.QueryCapsules(ctx, query)
+}
+
+func (s *LaunchesService) GetCapsule(ctx context.Context, id string) (model.Capsule, error) {
+	spaceX, err := s.spaceX()
+	if err != nil {
+		return model.Capsule{}, err
+	}
+	return spaceX.GetCapsule(ctx, id)
+}
+
+func (s *LaunchesService) QueryShips(ctx context.Context, query *api.CollectionQuery) (api.Page[model.Ship], error) {
+	spaceX, err := s.spaceX()
+	if err != nil {
+		return api.Page[model.Ship]{}, err
+	}
+	return spaceX.QueryShips(ctx, query)
+}
+
+func (s *LaunchesService) GetShip(ctx context.Context, id string) (model.Ship, error) {
+	spaceX, err := s.spaceX()
+	if err != nil {
+		return model.Ship{}, err
+	}
+	return spaceX.GetShip(ctx, id)
+}
+
+func (s *LaunchesService) QueryLandpads(ctx context.Context, query *api.CollectionQuery) (api.Page[model.Landpad], error) {
+	spaceX, err := s.spaceX()
+	if err != nil {
+		return api.Page[model.Landpad]{}, err
+	}
+	return spaceX.QueryLandpads(ctx, query)
 }
 
-func (s *LaunchesService) GetEarthEvents(ctx context.Context, longitude, latitude float64, date time.Time) ([]model.NasaEarthEvent, error) {
-	queryParams := api.BuildWeatherEventsQueryParams(longitude, latitude, date)
-	return s.nasaClient.GetEarthEvents(ctx, queryParams)
+func (s *LaunchesService) GetLandpad(ctx context.Context, id string) (model.Landpad, error) {
+	spaceX, err := s.spaceX()
+	if err != nil {
+		return model.Landpad{}, err
+	}
+	return spaceX.GetLandpad(ctx, id)
+}
+
+// GetCorePayloads returns every payload launched on the core's flights.
+func (s *LaunchesService) GetCorePayloads(ctx context.Context, core model.Core) ([]model.Payload, error) {
+	if len(core.Launches) == 0 {
+		return nil, nil
+	}
+	spaceX, err := s.spaceX()
+	if err != nil {
+		return nil, err
+	}
+	var payloads []model.Payload
+	query := api.NewCollectionQuery().In("launch", core.Launches...).Limit(100)
+	for payload, err := range api.IterateCollection(ctx, query, spaceX.QueryPayloads) {
+		if err != nil {
+			return payloads, err
+		}
+		payloads = append(payloads, payload)
+	}
+	return payloads, nil
+}
+
+// GetEarthEvents returns the natural events that came within the configured
+// hazard radius of the given position around date, nearest first.
+func (s *LaunchesService) GetEarthEvents(ctx context.Context, longitude, latitude float64, date time.Time) ([]model.NearbyEvent, error) {
+	radius := s.config.HazardRadiusKm
+	window := s.config.HazardWindowDays
+	events, err := s.nasaClient.GetEarthEvents(ctx, api.HazardQuery(longitude, latitude, date, radius, window))
+	if err != nil {
+		return nil, err
+	}
+
+	// EONET filters by whole days, so the window runs from the start of the
+	// first day to the end of the last.
+	day := date.UTC().Truncate(24 * time.Hour)
+	from := day.AddDate(0, 0, -window)
+	to := day.AddDate(0, 0, window+1).Add(-time.Nanosecond)
+
+	var nearby []model.NearbyEvent
+	for _, event := range events {
+		if near, ok := event.Nearest(latitude, longitude, from, to); ok && near.DistanceKm <= radius {
+			nearby = append(nearby, near)
+		}
+	}
+	slices.SortFunc(nearby, func(a, b model.NearbyEvent) int {
+		return cmp.Compare(a.DistanceKm, b.DistanceKm)
+	})
+	return nearby, nil
+}
+
+// GetLaunchWeather returns the POWER daily weather at a launchpad on the
+// (UTC) day of date. POWER has no forecasts and no data before
+// model.PowerFirstDay, so launches outside its range get a day without
+// values rather than a request.
+func (s *LaunchesService) GetLaunchWeather(ctx context.Context, latitude, longitude float64, date time.Time) (model.DailyWeather, error) {
+	day := date.UTC().Truncate(24 * time.Hour)
+	if day.After(time.Now()) || day.Before(model.PowerFirstDay) {
+		return model.DailyWeather{Date: day}, nil
+	}
+
+	days, err := s.nasaClient.GetPointWeather(ctx, latitude, longitude, day, day)
+	if err != nil {
+		return model.DailyWeather{}, err
+	}
+	for _, weather := range days {
+		if weather.Date.Equal(day) {
+			return weather, nil
+		}
+	}
+	return model.DailyWeather{Date: day}, nil
 }
 
 func (s *LaunchesService) GetAsteroids(ctx context.Context, date time.Time) (model.NasaAsteroid, error) {
-	queryParams := buildAsteroidsQueryParams(date)
+	queryParams := buildAsteroidsQueryParams(date, date)
 	return s.nasaClient.GetAsteroids(ctx, queryParams)
 }
 
+// GetAsteroidsBetween fetches the NeoWs feed for any date range. The feed
+// only serves 7 days per request, so the range is split into chunks that are
+// fetched concurrently and merged.
+func (s *LaunchesService) GetAsteroidsBetween(ctx context.Context, start, end time.Time) (model.NasaAsteroid, error) {
+	chunks := asteroidFeedChunks(start, end)
+	results := make([]model.NasaAsteroid, len(chunks))
+	errs := make([]error, len(chunks))
+
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, asteroidFeedConcurrency)
+	for i, chunk := range chunks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			slots <- struct{}{}
+			defer func() { <-slots }()
+			results[i], errs[i] = s.nasaClient.GetAsteroids(ctx, buildAsteroidsQueryParams(chunk.start, chunk.end))
+		}()
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			return model.NasaAsteroid{}, fmt.Errorf("failed to fetch asteroids for %s to %s: %w", chunks[i].start.Format(time.DateOnly), chunks[i].end.Format(time.DateOnly), err)
+		}
+	}
+
+	merged := model.NasaAsteroid{NearEarthObjects: make(map[string][]model.NasaAsteroidObject)}
+	for _, result := range results {
+		merged.ElementCount += result.ElementCount
+		for day, objects := range result.NearEarthObjects {
+			merged.NearEarthObjects[day] = append(merged.NearEarthObjects[day], objects...)
+		}
+	}
+	return merged, nil
+}
+
+func (s *LaunchesService) GetAPOD(ctx context.Context, query *api.APODQuery) ([]model.APOD, error) {
+	return s.nasaClient.GetAPOD(ctx, query)
+}
+
+// GetLaunchAPOD returns the Astronomy Picture of the Day for the (UTC) day of
+// a launch. It reports false for days without a picture yet, such as those
+// of upcoming launches.
+func (s *LaunchesService) GetLaunchAPOD(ctx context.Context, date time.Time, thumbs bool) (model.APOD, bool, error) {
+	day := date.UTC().Truncate(24 * time.Hour)
+	if day.After(latestAPODDate(time.Now())) {
+		return model.APOD{}, false, nil
+	}
+	apods, err := s.nasaClient.GetAPOD(ctx, api.NewAPODQuery().Date(day).Thumbs(thumbs))
+	if err != nil || len(apods) == 0 {
+		return model.APOD{}, false, err
+	}
+	return apods[0], true, nil
+}
+
+// latestAPODDate is the newest date APOD can have a picture for at now, as a
+// UTC midnight. Pictures are published on US Eastern dates, so for the first
+// hours of a UTC day the newest one is still yesterday's.
+func latestAPODDate(now time.Time) time.Time {
+	eastern, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		// Without tzdata, standard time errs on the side of asking later.
+		eastern = time.FixedZone("EST", -5*60*60)
+	}
+	year, month, day := now.In(eastern).Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// DownloadAPOD saves the media of apod into dir, named after its date, and
+// returns the file's path. With hd set the high resolution image is
+// preferred.
+func (s *LaunchesService) DownloadAPOD(ctx context.Context, apod model.APOD, dir string, hd bool) (string, error) {
+	mediaURL := apod.MediaURL(hd)
+	if mediaURL == "" {
+		return "", fmt.Errorf("no downloadable media for %s (%s)", apod.Date, apod.MediaType)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create download directory: %w", err)
+	}
+	path := filepath.Join(dir, apod.MediaFilename(mediaURL))
+
+	// Stream into a temporary file so a failed download never leaves a
+	// truncated file under the final name.
+	file, err := os.CreateTemp(dir, apod.MediaFilename(mediaURL)+".*.part")
+	if err != nil {
+		return "", fmt.Errorf("failed to save %s: %w", path, err)
+	}
+	defer os.Remove(file.Name())
+	_, err = s.nasaClient.DownloadMedia(ctx, mediaURL, file)
+	if closeErr := file.Close(); err == nil && closeErr != nil {
+		err = fmt.Errorf("failed to save %s: %w", path, closeErr)
+	}
+	if err != nil {
+		return "", err
+	}
+	if err := os.Chmod(file.Name(), 0o644); err != nil {
+		return "", fmt.Errorf("failed to save %s: %w", path, err)
+	}
+	if err := os.Rename(file.Name(), path); err != nil {
+		return "", fmt.Errorf("failed to save %s: %w", path, err)
+	}
+	return path, nil
+}
+
+// GetAsteroid looks up one near-Earth object by its NEO reference ID.
+func (s *LaunchesService) GetAsteroid(ctx context.Context, id string) (model.NasaAsteroidObject, error) {
+	return s.nasaClient.GetAsteroid(ctx, id)
+}
+
+// GetSpaceWeather fetches the flares, CMEs, geomagnetic storms and SEP
+// events DONKI reports from start to end. The four endpoints are queried
+// concurrently.
+func (s *LaunchesService) GetSpaceWeather(ctx context.Context, start, end time.Time) (model.SpaceWeather, error) {
+	var weather model.SpaceWeather
+	var errs [4]error
+	var wg sync.WaitGroup
+	wg.Add(len(errs))
+	go func() {
+		defer wg.Done()
+		weather.Flares, errs[0] = s.donkiClient.GetSolarFlares(ctx, start, end)
+	}()
+	go func() {
+		defer wg.Done()
+		weather.CMEs, errs[1] = s.donkiClient.GetCMEs(ctx, start, end)
+	}()
+	go func() {
+		defer wg.Done()
+		weather.Storms, errs[2] = s.donkiClient.GetGeomagneticStorms(ctx, start, end)
+	}()
+	go func() {
+		defer wg.Done()
+		weather.Particles, errs[3] = s.donkiClient.GetSEPs(ctx, start, end)
+	}()
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return model.SpaceWeather{}, err
+		}
+	}
+	return weather, nil
+}
+
+// GetLaunchSpaceWeather returns the space weather within the configured
+// number of days either side of a launch.
+func (s *LaunchesService) GetLaunchSpaceWeather(ctx context.Context, date time.Time) (model.SpaceWeather, error) {
+	window := s.config.SpaceWeatherWindowDays
+	return s.GetSpaceWeather(ctx, date.AddDate(0, 0, -window), date.AddDate(0, 0, window))
+}
+
+// NASAQuota returns the api.nasa.gov quota seen on the latest response.
+func (s *LaunchesService) NASAQuota() api.RateLimitStatus {
+	return s.nasaClient.Quota()
+}
+
 func LoadConfiguration() (*model.Config, error) {
+	config := readConfiguration()
+
+	// Other launch providers are configured under providers.<name>; see
+	// model.ProviderConfig.
+	if err := viper.UnmarshalKey("providers", &config.Providers); err != nil {
+		return nil, fmt.Errorf("invalid providers config: %w", err)
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	if err := validateProviders(config); err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+// validateProviders checks that every selected provider is built in or has a
+// registered type.
+func validateProviders(config *model.Config) error {
+	registered := api.RegisteredProviders()
+	for _, name := range config.ProviderNames() {
+		if name == model.ProviderSpaceX || name == model.ProviderLaunchLibrary {
+			continue
+		}
+		providerType := api.ProviderType(name, config.Providers[name])
+		if !slices.Contains(registered, providerType) {
+			if _, configured := config.Providers[name]; !configured {
+				return fmt.Errorf("unknown launch provider %q", name)
+			}
+			return fmt.Errorf("launch provider %s has unknown type %q", name, providerType)
+		}
+	}
+	return nil
+}
+
+// readConfiguration applies env vars, config file values and flags on top of
+// the defaults without validating the result.
+func readConfiguration() *model.Config {
 	config := model.DefaultConfig()
 
 	if nasaKey := os.Getenv("NASA_API_KEY"); nasaKey != "" {
 		config.NASAAPIKey = nasaKey
 	}
+	config.LL2APIToken = os.Getenv("LL2_API_TOKEN")
+	if config.LL2APIToken != "" {
+		config.LL2RateLimit = model.DefaultLL2TokenRateLimit
+	}
 
-	if err := config.Validate(); err != nil {
-		return nil, err
+	// Base URLs can come from the config file, SPACEX_BASE_URL style env
+	// vars or the matching persistent flags.
+	if baseURL := viper.GetString("spacex_base_url"); baseURL != "" {
+		config.SpaceXBaseURL = strings.TrimSuffix(baseURL, "/")
+	}
+	if baseURL := viper.GetString("nasa_base_url"); baseURL != "" {
+		config.NASABaseURL = strings.TrimSuffix(baseURL, "/")
+	}
+	if baseURL := viper.GetString("eonet_base_url"); baseURL != "" {
+		config.EONETBaseURL = strings.TrimSuffix(baseURL, "/")
+	}
+	if baseURL := viper.GetString("power_base_url"); baseURL != "" {
+		config.POWERBaseURL = strings.TrimSuffix(baseURL, "/")
+	}
+	if baseURL := viper.GetString("ll2_base_url"); baseURL != "" {
+		config.LL2BaseURL = strings.TrimSuffix(baseURL, "/")
+	}
+	if provider := viper.GetString("provider"); provider != "" {
+		config.Provider = strings.ToLower(provider)
 	}
 
-	return config, nil
+	if backoff := viper.GetString("backoff"); backoff != "" {
+		config.Backoff = backoff
+	}
+
+	config.CacheDir = viper.GetString("cache_dir")
+	config.NoCache = viper.GetBool("no_cache")
+	config.RefreshCache = viper.GetBool("refresh")
+	if viper.IsSet("cache_ttl_reference") {
+		config.ReferenceTTL = viper.GetDuration("cache_ttl_reference")
+	}
+	if viper.IsSet("cache_ttl_launches") {
+		config.LaunchesTTL = viper.GetDuration("cache_ttl_launches")
+	}
+	if viper.IsSet("cache_ttl_nasa") {
+		config.NASATTL = viper.GetDuration("cache_ttl_nasa")
+	}
+
+	if viper.IsSet("spacex_rate_limit") {
+		config.SpaceXRateLimit = viper.GetFloat64("spacex_rate_limit")
+	}
+	if viper.IsSet("nasa_rate_limit") {
+		config.NASARateLimit = viper.GetFloat64("nasa_rate_limit")
+	}
+	if viper.IsSet("eonet_rate_limit") {
+		config.EONETRateLimit = viper.GetFloat64("eonet_rate_limit")
+	}
+	if viper.IsSet("power_rate_limit") {
+		config.POWERRateLimit = viper.GetFloat64("power_rate_limit")
+	}
+	if viper.IsSet("ll2_rate_limit") {
+		config.LL2RateLimit = viper.GetFloat64("ll2_rate_limit")
+	}
+
+	if viper.IsSet("breaker_threshold") {
+		config.BreakerThreshold = viper.GetInt("breaker_threshold")
+	}
+	if viper.IsSet("breaker_cooldown") {
+		config.BreakerCooldown = viper.GetDuration("breaker_cooldown")
+	}
+
+	// The hazard flags default to the config defaults, so viper always
+	// resolves these keys and an explicit 0 is kept.
+	config.HazardRadiusKm = viper.GetFloat64("hazard_radius_km")
+	config.HazardWindowDays = viper.GetInt("hazard_window_days")
+
+	config.SpaceWeatherWindowDays = viper.GetInt("space_weather_days")
+
+	config.RecordDir = viper.GetString("record")
+	config.ReplayDir = viper.GetString("replay")
+
+	return config
+}
+
+// OpenCache opens the on-disk response cache described by config, falling
+// back to the user cache directory.
+func OpenCache(config *model.Config) (*api.Cache, error) {
+	dir := config.CacheDir
+	if dir == "" {
+		defaultDir, err := api.DefaultCacheDir()
+		if err != nil {
+			return nil, err
+		}
+		dir = defaultDir
+	}
+
+	return api.NewCache(dir, map[api.CacheClass]time.Duration{
+		api.CacheReference: config.ReferenceTTL,
+		api.CacheLaunches:  config.LaunchesTTL,
+		api.CacheNASA:      config.NASATTL,
+	})
 }
 
 func SetupLogger() *slog.Logger {
+	level := slog.LevelInfo
+	if viper.GetBool("verbose") {
+		level = slog.LevelDebug
+	}
 	opts := &slog.HandlerOptions{
-		Level: slog.LevelInfo,
+		Level: level,
 	}
 
 	handler := slog.NewTextHandler(os.Stdout, opts)
-	return slog.New(handler)
+	return slog.New(api.NewRedactingHandler(handler, os.Getenv("NASA_API_KEY"), os.Getenv("LL2_API_TOKEN")))
 }
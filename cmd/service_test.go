@@ -0,0 +1,473 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/MitiaRD/ReMarkable-cli/api"
+	"github.com/MitiaRD/ReMarkable-cli/api/apitest"
+	"github.com/MitiaRD/ReMarkable-cli/model"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func replayService(t *testing.T) *LaunchesService {
+	t.Helper()
+
+	config := model.DefaultConfig()
+	config.ReplayDir = "testdata/fixtures"
+	require.NoError(t, config.Validate())
+
+	service, err := NewLaunchesService(config, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.NoError(t, err)
+	return service
+}
+
+func TestNewLaunchesServiceFailsWithoutFixtures(t *testing.T) {
+	config := model.DefaultConfig()
+	config.ReplayDir = filepath.Join(t.TempDir(), "missing")
+
+	_, err := NewLaunchesService(config, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	assert.ErrorContains(t, err, "replay mode unavailable")
+}
+
+func TestRecordingBypassesWarmCache(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"crew-v1"`)
+		if r.Header.Get("If-None-Match") == `"crew-v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		io.WriteString(w, `[{"id":"bob","name":"Robert Behnken"}]`)
+	}))
+	defer server.Close()
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	run := func(config *model.Config) map[string]model.Crew {
+		t.Helper()
+		service, err := NewLaunchesService(config, logger)
+		require.NoError(t, err)
+		crew, err := service.GetCrewMembers(context.Background())
+		require.NoError(t, err)
+		return crew
+	}
+	newConfig := func() *model.Config {
+		config := model.DefaultConfig()
+		config.SpaceXBaseURL = server.URL
+		config.CacheDir = filepath.Join(t.TempDir(), "cache")
+		return config
+	}
+
+	config := newConfig()
+	run(config)
+
+	record := *config
+	record.RecordDir = filepath.Join(t.TempDir(), "fixtures")
+	run(&record)
+
+	replay := newConfig()
+	replay.ReplayDir = record.RecordDir
+	assert.Equal(t, "Robert Behnken", run(replay)["bob"].Name)
+}
+
+func TestLaunchesServiceReplaysFixtures(t *testing.T) {
+	service := replayService(t)
+	ctx := context.Background()
+
+	cmd := &cobra.Command{}
+	cmd.Flags().Int("limit", 0, "Limit number of results")
+	cmd.Flags().Set("limit", "2")
+	query, err := buildLaunchQuery(cmd)
+	require.NoError(t, err)
+
+	page, err := service.QueryLaunches(ctx, query)
+	require.NoError(t, err)
+	require.Len(t, page.Docs, 2)
+	assert.Equal(t, "Demo-2", page.Docs[0].Name)
+	assert.False(t, page.HasNextPage)
+
+	rockets, err := service.GetRockets(ctx)
+	require.NoError(t, err)
+	total, err := getCosts(page.Docs, rockets)
+	require.NoError(t, err)
+	assert.Equal(t, 140000000, total)
+
+	crew, err := service.GetCrewMembers(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "Robert Behnken", crew[page.Docs[0].Crew[0]].Name)
+
+	launchpads, err := service.GetLaunchpads(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "Cape Canaveral", launchpads[page.Docs[0].LaunchpadId].Locality)
+}
+
+func TestLaunchesServiceWithFakeClients(t *testing.T) {
+	launchDate := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	spaceX := apitest.NewSpaceX().
+		WithRockets(model.Rocket{ID: "falcon9", CostPerLaunch: 50000000}).
+		WithLaunches(
+			model.Launch{ID: "a", Date: launchDate, RocketId: "falcon9"},
+			model.Launch{ID: "b", Date: launchDate.AddDate(0, 1, 0), RocketId: "falcon9"},
+			model.Launch{ID: "c", Date: launchDate.AddDate(0, 2, 0), RocketId: "falcon9"},
+		)
+	nasa := apitest.NewNASA().
+		WithAsteroids(launchDate, model.NasaAsteroidObject{Hazardous: true}).
+		FailOn("GetEarthEvents", errors.New("EONET unavailable"))
+
+	service := NewLaunchesServiceWithClients(Clients{SpaceX: spaceX, NASA: nasa}, model.DefaultConfig(), slog.New(slog.NewTextHandler(io.Discard, nil)))
+	ctx := context.Background()
+
+	launches, err := service.GetAllLaunches(ctx, api.NewLaunchQuery().SortBy("date_utc", api.Descending).Limit(2))
+	require.NoError(t, err)
+	require.Len(t, launches, 3)
+	assert.Equal(t, "c", launches[0].ID)
+
+	rockets, err := service.GetRockets(ctx)
+	require.NoError(t, err)
+	total, err := getCosts(launches, rockets)
+	require.NoError(t, err)
+	assert.Equal(t, 150000000, total)
+
+	asteroids, err := service.GetAsteroids(ctx, launchDate)
+	require.NoError(t, err)
+	assert.Equal(t, 1, asteroids.ElementCount)
+
+	_, err = service.GetEarthEvents(ctx, -80.6, 28.6, launchDate)
+	assert.EqualError(t, err, "EONET unavailable")
+}
+
+func TestGetEarthEventsFiltersByRadius(t *testing.T) {
+	launchDate := time.Date(2024, 9, 26, 12, 0, 0, 0, time.UTC)
+	event := func(id string, lon, lat float64, observed time.Time) model.NasaEarthEvent {
+		return model.NasaEarthEvent{ID: id, Geometry: []model.EventGeometry{
+			{Type: model.GeometryPoint, Point: []float64{lon, lat}, Date: observed},
+		}}
+	}
+	nasa := apitest.NewNASA().WithEvents(
+		event("corner-of-box", -79.0, 30.0, launchDate),
+		event("close", -80.4, 28.5, launchDate.AddDate(0, 0, -2)),
+		event("closer", -80.6, 28.7, launchDate),
+		event("too-late", -80.6, 28.6, launchDate.AddDate(0, 0, 3)),
+	)
+
+	config := model.DefaultConfig()
+	config.HazardRadiusKm = 200
+	config.HazardWindowDays = 2
+	service := NewLaunchesServiceWithClients(Clients{SpaceX: apitest.NewSpaceX(), NASA: nasa}, config, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	nearby, err := service.GetEarthEvents(context.Background(), -80.6, 28.6, launchDate)
+	require.NoError(t, err)
+
+	var ids []string
+	for _, near := range nearby {
+		ids = append(ids, near.Event.ID)
+	}
+	assert.Equal(t, []string{"closer", "close"}, ids)
+	assert.InDelta(t, 11.1, nearby[0].DistanceKm, 0.5)
+}
+
+func TestGetLaunchWeather(t *testing.T) {
+	launchDate := time.Date(2024, 9, 26, 22, 30, 0, 0, time.UTC)
+	temperature, wind, rain := 27.1, 6.5, 12.3
+	nasa := apitest.NewNASA().WithWeather(model.DailyWeather{
+		Date:          time.Date(2024, 9, 26, 0, 0, 0, 0, time.UTC),
+		Temperature:   &temperature,
+		WindSpeed:     &wind,
+		Precipitation: &rain,
+	})
+	service := NewLaunchesServiceWithClients(Clients{NASA: nasa}, model.DefaultConfig(), slog.New(slog.NewTextHandler(io.Discard, nil)))
+	ctx := context.Background()
+
+	weather, err := service.GetLaunchWeather(ctx, 28.6, -80.6, launchDate)
+	require.NoError(t, err)
+	assert.True(t, weather.Available())
+	assert.Equal(t, "2024-09-26: 27.1°C, wind 6.5 m/s, precipitation 12.3 mm", describeWeather(weather))
+
+	weather, err = service.GetLaunchWeather(ctx, 28.6, -80.6, launchDate.AddDate(0, 0, 1))
+	require.NoError(t, err)
+	assert.False(t, weather.Available())
+
+	weather, err = service.GetLaunchWeather(ctx, 28.6, -80.6, time.Now().AddDate(0, 1, 0))
+	require.NoError(t, err)
+	assert.False(t, weather.Available())
+
+	apollo11 := time.Date(1969, 7, 16, 13, 32, 0, 0, time.UTC)
+	weather, err = service.GetLaunchWeather(ctx, 28.6, -80.6, apollo11)
+	require.NoError(t, err)
+	assert.False(t, weather.Available())
+	assert.Equal(t, time.Date(1969, 7, 16, 0, 0, 0, 0, time.UTC), weather.Date)
+	assert.Equal(t, []string{"GetPointWeather", "GetPointWeather"}, nasa.Calls(), "days outside POWER's range are not requested")
+}
+
+func TestLaunchesServiceMergesProviders(t *testing.T) {
+	launchDate := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	crsID := "0d779392-1a36-4c1e-b0b8-ec11e3031ee6"
+	spaceX := apitest.NewSpaceX().
+		WithRockets(model.Rocket{ID: "falcon9", Name: "Falcon 9"}).
+		WithLaunches(
+			model.Launch{ID: "crs-30", Name: "CRS-30", Date: launchDate, RocketId: "falcon9", LaunchLibraryID: &crsID},
+			model.Launch{ID: "starlink", Name: "Starlink 6-41", Date: launchDate.AddDate(0, 0, 2), RocketId: "falcon9"},
+		)
+	ll2 := apitest.NewLaunchLibrary().
+		WithRockets(model.Rocket{ID: "26", Name: "Electron"}).
+		WithLaunchpads(model.Launchpad{ID: "166", Name: "Rocket Lab Launch Complex 1A"}).
+		WithLaunches(
+			model.Launch{ID: crsID, Name: "SpX CRS-30", Date: launchDate, Agency: "SpaceX", LaunchLibraryID: &crsID},
+			model.Launch{ID: "electron", Name: "Owl Night Long", Date: launchDate.AddDate(0, 0, 1), Agency: "Rocket Lab", RocketId: "26", LaunchpadId: "166"},
+			model.Launch{ID: "starlink-ll2", Name: "Starlink Group 6-41", Date: launchDate.AddDate(0, 0, 2).Add(20 * time.Second), Agency: "SpaceX"},
+		)
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	ctx := context.Background()
+	query := api.NewLaunchQuery().SortBy("date_utc", api.Descending).Limit(2)
+
+	tests := []struct {
+		provider string
+		expected []string
+	}{
+		{provider: model.ProviderSpaceX, expected: []string{"starlink", "crs-30"}},
+		{provider: model.ProviderLaunchLibrary, expected: []string{"starlink-ll2", "electron", crsID}},
+		{provider: model.ProviderAll, expected: []string{"starlink", "electron", "crs-30"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.provider, func(t *testing.T) {
+			config := model.DefaultConfig()
+			config.Provider = tt.provider
+			service := NewLaunchesServiceWithClients(Clients{SpaceX: spaceX, LaunchLibrary: ll2}, config, logger)
+
+			launches, err := service.GetAllLaunches(ctx, query)
+			require.NoError(t, err)
+			var ids []string
+			for _, launch := range launches {
+				ids = append(ids, launch.ID)
+			}
+			assert.Equal(t, tt.expected, ids)
+		})
+	}
+
+	config := model.DefaultConfig()
+	config.Provider = model.ProviderAll
+	service := NewLaunchesServiceWithClients(Clients{SpaceX: spaceX, LaunchLibrary: ll2}, config, logger)
+
+	page, err := service.QueryLaunches(ctx, query)
+	require.NoError(t, err)
+	require.Len(t, page.Docs, 3, "the first page of each provider, without the duplicate Starlink launch")
+	assert.Equal(t, model.ProviderSpaceX, page.Docs[0].Provider)
+	assert.Equal(t, "SpaceX", page.Docs[0].Agency)
+	assert.Equal(t, model.ProviderLaunchLibrary, page.Docs[1].Provider)
+	assert.Equal(t, "Electron", page.Docs[1].Rocket.Name)
+	assert.Equal(t, 4, page.TotalDocs)
+	assert.True(t, page.HasNextPage)
+
+	rockets, err := service.GetRockets(ctx)
+	require.NoError(t, err)
+	assert.Len(t, rockets, 2)
+}
+
+func TestLaunchesServiceSkipsFailingProvider(t *testing.T) {
+	spaceX := apitest.NewSpaceX().WithLaunches(model.Launch{ID: "crs-30"})
+	ll2 := apitest.NewLaunchLibrary().FailAll(&api.StatusError{StatusCode: 429})
+
+	config := model.DefaultConfig()
+	config.Provider = model.ProviderAll
+	service := NewLaunchesServiceWithClients(Clients{SpaceX: spaceX, LaunchLibrary: ll2}, config, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	ctx := context.Background()
+
+	launches, err := service.GetLaunches(ctx, api.NewLaunchQuery())
+	require.NoError(t, err)
+	require.Len(t, launches, 1)
+	assert.Equal(t, "crs-30", launches[0].ID)
+
+	config.Provider = model.ProviderLaunchLibrary
+	service = NewLaunchesServiceWithClients(Clients{SpaceX: spaceX, LaunchLibrary: ll2}, config, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	_, err = service.GetLaunches(ctx, api.NewLaunchQuery())
+	var statusErr *api.StatusError
+	assert.ErrorAs(t, err, &statusErr)
+}
+
+func TestLaunchesServiceUsesConfiguredProviders(t *testing.T) {
+	launchDate := time.Date(2026, 11, 1, 9, 30, 0, 0, time.UTC)
+	spaceX := apitest.NewSpaceX().
+		WithRockets(model.Rocket{ID: "falcon9", Name: "Falcon 9"}).
+		WithLaunches(model.Launch{ID: "starlink", Date: launchDate.AddDate(0, 0, 1), RocketId: "falcon9"})
+	manifest := apitest.NewLaunchLibrary().
+		WithName("manifest").
+		WithRockets(model.Rocket{ID: "r-1", Name: "Sounding Rocket"}).
+		WithLaunches(model.Launch{ID: "m-1", Date: launchDate, RocketId: "r-1", Agency: "Internal"})
+	clients := Clients{SpaceX: spaceX, Providers: map[string]api.LaunchProvider{"manifest": manifest}}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	ctx := context.Background()
+
+	tests := []struct {
+		provider string
+		expected []string
+	}{
+		{provider: "manifest", expected: []string{"manifest"}},
+		{provider: "spacex, manifest", expected: []string{"spacex", "manifest"}},
+		{provider: "manifest,spacex,manifest", expected: []string{"manifest", "spacex"}},
+		{provider: model.ProviderAll, expected: []string{"spacex", "manifest"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.provider, func(t *testing.T) {
+			config := model.DefaultConfig()
+			config.NASAAPIKey = "DEMO_KEY"
+			config.Provider = tt.provider
+			config.Providers = map[string]model.ProviderConfig{"manifest": {Command: "manifest-provider"}}
+			require.NoError(t, config.Validate())
+			service := NewLaunchesServiceWithClients(clients, config, logger)
+
+			var names []string
+			for _, provider := range service.providers {
+				names = append(names, provider.Name())
+			}
+			assert.Equal(t, tt.expected, names)
+		})
+	}
+
+	config := model.DefaultConfig()
+	config.Provider = "spacex,manifest"
+	service := NewLaunchesServiceWithClients(clients, config, logger)
+	launches, err := service.GetAllLaunches(ctx, api.NewLaunchQuery().SortBy("date_utc", api.Ascending))
+	require.NoError(t, err)
+	require.Len(t, launches, 2)
+	assert.Equal(t, "manifest", launches[0].Provider)
+	assert.Equal(t, model.ProviderSpaceX, launches[1].Provider)
+
+	rockets, err := service.GetRockets(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "Sounding Rocket", rockets["r-1"].Name)
+	assert.Equal(t, "Falcon 9", rockets["falcon9"].Name)
+}
+
+func TestValidateProviders(t *testing.T) {
+	tests := []struct {
+		name      string
+		provider  string
+		providers map[string]model.ProviderConfig
+		wantErr   string
+	}{
+		{name: "built in", provider: "spacex,ll2"},
+		{name: "external", provider: "spacex,manifest", providers: map[string]model.ProviderConfig{"manifest": {Command: "manifest-provider"}}},
+		{name: "unconfigured", provider: "spacex,manifest", wantErr: `unknown launch provider "manifest"`},
+		{name: "unregistered type", provider: "manifest", providers: map[string]model.ProviderConfig{"manifest": {Type: "oracle"}}, wantErr: `launch provider manifest has unknown type "oracle"`},
+		{name: "all", provider: model.ProviderAll, providers: map[string]model.ProviderConfig{"manifest": {}}, wantErr: `launch provider manifest has unknown type "manifest"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := model.DefaultConfig()
+			config.Provider = tt.provider
+			config.Providers = tt.providers
+
+			err := validateProviders(config)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestLaunchesServiceGetsHardwareByID(t *testing.T) {
+	spaceX := apitest.NewSpaceX().
+		WithCores(model.Core{ID: "c-1", Serial: "B1049"}, model.Core{ID: "c-2", Serial: "B1051"}, model.Core{ID: "c-3", Serial: "B1058"}).
+		WithLandpads(model.Landpad{ID: "ocisly", Name: "OCISLY"})
+	service := NewLaunchesServiceWithClients(Clients{SpaceX: spaceX}, model.DefaultConfig(), slog.New(slog.NewTextHandler(io.Discard, nil)))
+	ctx := context.Background()
+
+	cores, err := service.GetCores(ctx, "c-1", "c-3")
+	require.NoError(t, err)
+	assert.Len(t, cores, 2)
+	assert.Equal(t, "B1058", cores["c-3"].Serial)
+
+	landpads, err := service.GetLandpads(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, landpads)
+	assert.Equal(t, []string{"QueryCores"}, spaceX.Calls(), "only the referenced IDs are queried")
+}
+
+func TestLaunchesServiceLooksUpOnlyMissingReferences(t *testing.T) {
+	launchDate := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	spaceX := apitest.NewSpaceX().
+		WithRockets(model.Rocket{ID: "falcon9", Name: "Falcon 9"}).
+		WithLaunchpads(model.Launchpad{ID: "slc40", Name: "SLC 40"}).
+		WithLaunches(model.Launch{ID: "crs-30", Date: launchDate, RocketId: "falcon9", LaunchpadId: "slc40"})
+	ll2 := apitest.NewLaunchLibrary().
+		WithRockets(model.Rocket{ID: "26", Name: "Electron"}).
+		WithLaunchpads(model.Launchpad{ID: "166", Name: "Rocket Lab Launch Complex 1A"}).
+		WithLaunches(model.Launch{ID: "electron", Date: launchDate.AddDate(0, 0, 1), Agency: "Rocket Lab", RocketId: "26", LaunchpadId: "166"})
+
+	config := model.DefaultConfig()
+	config.Provider = model.ProviderAll
+	service := NewLaunchesServiceWithClients(Clients{SpaceX: spaceX, LaunchLibrary: ll2}, config, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	ctx := context.Background()
+
+	launches, err := service.GetLaunches(ctx, api.NewLaunchQuery())
+	require.NoError(t, err)
+	require.Len(t, launches, 2)
+
+	rockets, err := service.LookupRockets(ctx, launches)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]model.Rocket{"falcon9": {ID: "falcon9", Name: "Falcon 9"}}, rockets)
+	launchpads, err := service.LookupLaunchpads(ctx, launches)
+	require.NoError(t, err)
+	assert.Contains(t, launchpads, "slc40")
+	assert.Equal(t, []string{"QueryLaunches"}, ll2.Calls(), "Launch Library launches embed their references")
+
+	rockets, err = service.LookupRockets(ctx, launches[1:])
+	require.NoError(t, err)
+	assert.Empty(t, rockets)
+}
+
+func TestReadConfigurationHazardWindow(t *testing.T) {
+	config := readConfiguration()
+	assert.Equal(t, float64(model.DefaultHazardRadiusKm), config.HazardRadiusKm)
+	assert.Equal(t, model.DefaultHazardWindowDays, config.HazardWindowDays)
+
+	viper.Set("hazard_window_days", 0)
+	t.Cleanup(func() { viper.Set("hazard_window_days", nil) })
+	assert.Zero(t, readConfiguration().HazardWindowDays)
+}
+
+func TestReadConfigurationLL2RateLimit(t *testing.T) {
+	t.Setenv("LL2_API_TOKEN", "")
+	assert.Equal(t, model.DefaultLL2RateLimit, readConfiguration().LL2RateLimit)
+
+	t.Setenv("LL2_API_TOKEN", "ll2-token-0123456789")
+	assert.Equal(t, float64(model.DefaultLL2TokenRateLimit), readConfiguration().LL2RateLimit)
+
+	viper.Set("ll2_rate_limit", 0.5)
+	t.Cleanup(func() { viper.Set("ll2_rate_limit", nil) })
+	assert.Equal(t, 0.5, readConfiguration().LL2RateLimit)
+}
+
+func TestLaunchesServiceWithoutSpaceX(t *testing.T) {
+	config := model.DefaultConfig()
+	config.Provider = model.ProviderLaunchLibrary
+	service := NewLaunchesServiceWithClients(Clients{LaunchLibrary: apitest.NewLaunchLibrary()}, config, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	ctx := context.Background()
+
+	_, err := service.GetCrewMembers(ctx)
+	assert.EqualError(t, err, "no SpaceX client configured")
+	_, err = service.QueryPayloads(ctx, nil)
+	assert.EqualError(t, err, "no SpaceX client configured")
+	_, err = service.GetCorePayloads(ctx, model.Core{Launches: []string{"l1"}})
+	assert.EqualError(t, err, "no SpaceX client configured")
+	_, err = service.GetCores(ctx, "c1")
+	assert.EqualError(t, err, "no SpaceX client configured")
+
+	landpads, err := service.GetLandpads(ctx)
+	require.NoError(t, err, "nothing to look up needs no client")
+	assert.Empty(t, landpads)
+}
@@ -0,0 +1,169 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/MitiaRD/ReMarkable-cli/model"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var spaceWeatherCmd = &cobra.Command{
+	Use:   "spaceweather",
+	Short: "Show solar flares, CMEs, geomagnetic storms and SEP events around launches",
+	Long: `Spaceweather lists the space weather reported by NASA DONKI within a window
+of each matching launch: solar flares (FLR), coronal mass ejections (CME),
+geomagnetic storms (GST) and solar energetic particle events (SEP).
+
+Available subcommands:
+  start        - Start date (YYYY-MM-DD),
+  end          - End date (YYYY-MM-DD),
+  limit        - Number of launches to show,
+  failed       - Filter for failed launches only,
+  upcoming     - Filter for upcoming launches only,
+  days         - Days either side of each launch to report (default 1)`,
+	PreRun: func(cmd *cobra.Command, args []string) {
+		viper.BindPFlag("space_weather_days", cmd.Flags().Lookup("days"))
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+		defer cancel()
+
+		config, err := LoadConfiguration()
+		if err != nil {
+			fmt.Printf("Error loading configuration: %v\n", err)
+			return
+		}
+
+		logger := SetupLogger()
+		service, err := NewLaunchesService(config, logger)
+		if err != nil {
+			fmt.Printf("Error setting up API clients: %v\n", err)
+			return
+		}
+
+		query, err := buildLaunchQuery(cmd)
+		if err != nil {
+			fmt.Printf("Error building launch query: %v\n", err)
+			return
+		}
+		launches, err := service.GetLaunches(ctx, query)
+		if err != nil {
+			logger.Error("failed to fetch launches", "error", err)
+			fmt.Printf("Error fetching launches: %v\n", err)
+			return
+		}
+
+		fmt.Printf("\n☀️  Space weather within %d days of each launch (showing %d launches):\n", config.SpaceWeatherWindowDays, len(launches))
+		fmt.Println(strings.Repeat("-", 80))
+		for _, launch := range launches {
+			fmt.Printf("📅 %s  %s\n", launch.DisplayDate(), launch.Name)
+			weather, err := service.GetLaunchSpaceWeather(ctx, launch.Date)
+			if err != nil {
+				logger.Error("failed to fetch space weather", "error", err)
+				fmt.Printf("   ☀️  Space weather unavailable: %s\n\n", unavailableReason(err))
+				continue
+			}
+			printSpaceWeather(weather, launch.Date)
+			fmt.Println()
+		}
+	},
+}
+
+// summarizeSpaceWeather describes the events in one line, e.g. "3 flares
+// (strongest X1.2), 1 CME (fastest 900 km/s)".
+func summarizeSpaceWeather(weather model.SpaceWeather) string {
+	if weather.Empty() {
+		return "no space weather events"
+	}
+
+	parts := []string{}
+	if flare, ok := weather.StrongestFlare(); ok {
+		parts = append(parts, fmt.Sprintf("%s (strongest %s)", plural(len(weather.Flares), "flare", "flares"), flare.ClassType))
+	}
+	if len(weather.CMEs) > 0 {
+		description := plural(len(weather.CMEs), "CME", "CMEs")
+		fastest := 0.0
+		for _, cme := range weather.CMEs {
+			if speed, ok := cme.Speed(); ok {
+				fastest = max(fastest, speed)
+			}
+		}
+		if fastest > 0 {
+			description += fmt.Sprintf(" (fastest %.0f km/s)", fastest)
+		}
+		parts = append(parts, description)
+	}
+	if len(weather.Storms) > 0 {
+		maxKp := 0.0
+		for _, storm := range weather.Storms {
+			maxKp = max(maxKp, storm.MaxKp())
+		}
+		parts = append(parts, fmt.Sprintf("%s (max Kp %g)", plural(len(weather.Storms), "geomagnetic storm", "geomagnetic storms"), maxKp))
+	}
+	if len(weather.Particles) > 0 {
+		parts = append(parts, plural(len(weather.Particles), "SEP event", "SEP events"))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// printSpaceWeather lists every event with its offset from the launch.
+func printSpaceWeather(weather model.SpaceWeather, launch time.Time) {
+	if weather.Empty() {
+		fmt.Printf("   ☀️  No space weather events\n")
+		return
+	}
+	for _, flare := range weather.Flares {
+		at := flare.PeakTime.Time
+		if at.IsZero() {
+			at = flare.BeginTime.Time
+		}
+		fmt.Printf("   ☀️  %s flare from %s %s\n", flare.ClassType, flare.SourceLocation, relativeTo(at, launch))
+	}
+	for _, cme := range weather.CMEs {
+		speed := ""
+		if s, ok := cme.Speed(); ok {
+			speed = fmt.Sprintf(" at %.0f km/s", s)
+		}
+		fmt.Printf("   💥 CME%s %s\n", speed, relativeTo(cme.StartTime.Time, launch))
+	}
+	for _, storm := range weather.Storms {
+		fmt.Printf("   🧲 Geomagnetic storm, max Kp %g, %s\n", storm.MaxKp(), relativeTo(storm.StartTime.Time, launch))
+	}
+	for _, sep := range weather.Particles {
+		fmt.Printf("   ⚛️  Solar energetic particles %s\n", relativeTo(sep.EventTime.Time, launch))
+	}
+}
+
+// relativeTo formats t along with how long before or after launch it was.
+func relativeTo(t, launch time.Time) string {
+	offset := t.Sub(launch)
+	direction := "after"
+	if offset < 0 {
+		offset = -offset
+		direction = "before"
+	}
+	hours := int(offset.Round(time.Hour).Hours())
+	return fmt.Sprintf("at %s (%dd %dh %s launch)", t.UTC().Format("2006-01-02 15:04"), hours/24, hours%24, direction)
+}
+
+func plural(n int, singular, pluralForm string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, singular)
+	}
+	return fmt.Sprintf("%d %s", n, pluralForm)
+}
+
+func init() {
+	rootCmd.AddCommand(spaceWeatherCmd)
+
+	spaceWeatherCmd.Flags().IntP("limit", "l", 10, "Number of launches to show")
+	spaceWeatherCmd.Flags().StringP("start", "s", "", "Start date (YYYY-MM-DD)")
+	spaceWeatherCmd.Flags().StringP("end", "e", "", "End date (YYYY-MM-DD)")
+	spaceWeatherCmd.Flags().BoolP("failed", "f", false, "Filter for failed launches only")
+	spaceWeatherCmd.Flags().BoolP("upcoming", "u", false, "Filter for upcoming launches only")
+	spaceWeatherCmd.Flags().Int("days", model.DefaultSpaceWeatherWindowDays, "Report space weather up to this many days either side of each launch")
+}
@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/MitiaRD/ReMarkable-cli/api/apitest"
+	"github.com/MitiaRD/ReMarkable-cli/model"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetLaunchSpaceWeather(t *testing.T) {
+	launchDate := time.Date(2024, 5, 12, 18, 0, 0, 0, time.UTC)
+	at := func(offset time.Duration) model.DONKITime { return model.DONKITime{Time: launchDate.Add(offset)} }
+	speed := 1200.0
+	donki := apitest.NewDONKI().
+		WithFlares(
+			model.SolarFlare{ID: "in-window", ClassType: "M5.0", BeginTime: at(-20 * time.Hour)},
+			model.SolarFlare{ID: "strongest", ClassType: "X1.1", BeginTime: at(10 * time.Hour)},
+			model.SolarFlare{ID: "too-early", ClassType: "X8.7", BeginTime: at(-72 * time.Hour)},
+		).
+		WithCMEs(model.CME{ActivityID: "cme", StartTime: at(time.Hour), Analyses: []model.CMEAnalysis{{Speed: &speed, IsMostAccurate: true}}}).
+		WithStorms(model.GeomagneticStorm{ID: "gst", StartTime: at(-6 * time.Hour), KpIndex: []model.KpIndex{{KpIndex: 7.67}, {KpIndex: 9}}})
+
+	config := model.DefaultConfig()
+	config.SpaceWeatherWindowDays = 1
+	service := NewLaunchesServiceWithClients(Clients{DONKI: donki}, config, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	weather, err := service.GetLaunchSpaceWeather(context.Background(), launchDate)
+	require.NoError(t, err)
+	assert.Len(t, weather.Flares, 2)
+	assert.Len(t, weather.CMEs, 1)
+	assert.Len(t, weather.Storms, 1)
+	assert.Empty(t, weather.Particles)
+	assert.ElementsMatch(t, []string{"GetSolarFlares", "GetCMEs", "GetGeomagneticStorms", "GetSEPs"}, donki.Calls())
+
+	assert.Equal(t, "2 flares (strongest X1.1), 1 CME (fastest 1200 km/s), 1 geomagnetic storm (max Kp 9)", summarizeSpaceWeather(weather))
+	assert.Equal(t, "no space weather events", summarizeSpaceWeather(model.SpaceWeather{}))
+
+	donki.FailOn("GetCMEs", errors.New("DONKI unavailable"))
+	_, err = service.GetLaunchSpaceWeather(context.Background(), launchDate)
+	assert.EqualError(t, err, "DONKI unavailable")
+}
+
+func TestRelativeTo(t *testing.T) {
+	launch := time.Date(2024, 5, 12, 18, 0, 0, 0, time.UTC)
+	assert.Equal(t, "at 2024-05-11 16:00 (1d 2h before launch)", relativeTo(launch.Add(-26*time.Hour), launch))
+	assert.Equal(t, "at 2024-05-12 21:10 (0d 3h after launch)", relativeTo(launch.Add(3*time.Hour+10*time.Minute), launch))
+}
+
+func TestSpaceWeatherDaysFlag(t *testing.T) {
+	cmd := &cobra.Command{}
+	cmd.Flags().Int("days", model.DefaultSpaceWeatherWindowDays, "")
+	spaceWeatherCmd.PreRun(cmd, nil)
+	t.Cleanup(func() { launchesCmd.PreRun(launchesCmd, nil) })
+
+	assert.Equal(t, model.DefaultSpaceWeatherWindowDays, readConfiguration().SpaceWeatherWindowDays)
+
+	require.NoError(t, cmd.Flags().Set("days", "0"))
+	assert.Zero(t, readConfiguration().SpaceWeatherWindowDays)
+}
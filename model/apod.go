@@ -0,0 +1,62 @@
+package model
+
+import (
+	"net/url"
+	"path"
+	"strings"
+	"time"
+)
+
+// APOD media types.
+const (
+	MediaImage = "image"
+	MediaVideo = "video"
+)
+
+// APOD is one Astronomy Picture of the Day entry.
+type APOD struct {
+	Date        string `json:"date"`
+	Title       string `json:"title"`
+	Explanation string `json:"explanation"`
+	Copyright   string `json:"copyright"`
+	MediaType   string `json:"media_type"`
+	URL         string `json:"url"`
+	HDURL       string `json:"hdurl"`
+	// ThumbnailURL is only set for videos requested with thumbnails.
+	ThumbnailURL   string `json:"thumbnail_url"`
+	ServiceVersion string `json:"service_version"`
+}
+
+// Day returns the APOD date, or the zero time if it is malformed.
+func (a APOD) Day() time.Time {
+	day, _ := time.Parse(time.DateOnly, a.Date)
+	return day
+}
+
+// MediaURL returns the URL of the file to download: the HD image if hd is
+// set and available, the image, or a video's thumbnail. Videos without a
+// thumbnail have nothing to download and return "".
+func (a APOD) MediaURL(hd bool) string {
+	switch a.MediaType {
+	case MediaImage:
+		if hd && a.HDURL != "" {
+			return a.HDURL
+		}
+		return a.URL
+	default:
+		return a.ThumbnailURL
+	}
+}
+
+// MediaFilename names a downloaded media file after the APOD date, keeping
+// the extension of mediaURL, e.g. "2024-09-26.jpg".
+func (a APOD) MediaFilename(mediaURL string) string {
+	ext := ""
+	if u, err := url.Parse(mediaURL); err == nil {
+		ext = path.Ext(u.Path)
+	}
+	if ext == "" || len(ext) > 5 {
+		ext = ".jpg"
+	}
+	return a.Date + strings.ToLower(ext)
+}
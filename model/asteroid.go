@@ -0,0 +1,175 @@
+package model
+
+import (
+	"cmp"
+	"slices"
+	"time"
+)
+
+// NasaAsteroidObject is a near-Earth object as returned by the NeoWs feed.
+type NasaAsteroidObject struct {
+	ID                string            `json:"id"`
+	NEOReferenceID    string            `json:"neo_reference_id"`
+	Name              string            `json:"name"`
+	NasaJPLURL        string            `json:"nasa_jpl_url"`
+	AbsoluteMagnitude float64           `json:"absolute_magnitude_h"`
+	Diameter          EstimatedDiameter `json:"estimated_diameter"`
+	Hazardous         bool              `json:"is_potentially_hazardous_asteroid"`
+	SentryObject      bool              `json:"is_sentry_object"`
+	CloseApproaches   []CloseApproach   `json:"close_approach_data"`
+	// OrbitalData is only returned by the lookup and browse endpoints.
+	OrbitalData *OrbitalData `json:"orbital_data,omitempty"`
+}
+
+// OrbitalData holds the orbit elements of an object. NeoWs sends most of the
+// numbers as strings.
+type OrbitalData struct {
+	OrbitID                   string     `json:"orbit_id"`
+	OrbitDeterminationDate    string     `json:"orbit_determination_date"`
+	FirstObservationDate      string     `json:"first_observation_date"`
+	LastObservationDate       string     `json:"last_observation_date"`
+	DataArcInDays             int        `json:"data_arc_in_days"`
+	ObservationsUsed          int        `json:"observations_used"`
+	OrbitUncertainty          string     `json:"orbit_uncertainty"`
+	MinimumOrbitIntersection  float64    `json:"minimum_orbit_intersection,string"`
+	JupiterTisserandInvariant float64    `json:"jupiter_tisserand_invariant,string"`
+	EpochOsculation           float64    `json:"epoch_osculation,string"`
+	Eccentricity              float64    `json:"eccentricity,string"`
+	SemiMajorAxis             float64    `json:"semi_major_axis,string"`
+	Inclination               float64    `json:"inclination,string"`
+	AscendingNodeLongitude    float64    `json:"ascending_node_longitude,string"`
+	OrbitalPeriod             float64    `json:"orbital_period,string"`
+	PerihelionDistance        float64    `json:"perihelion_distance,string"`
+	PerihelionArgument        float64    `json:"perihelion_argument,string"`
+	AphelionDistance          float64    `json:"aphelion_distance,string"`
+	PerihelionTime            float64    `json:"perihelion_time,string"`
+	MeanAnomaly               float64    `json:"mean_anomaly,string"`
+	MeanMotion                float64    `json:"mean_motion,string"`
+	Equinox                   string     `json:"equinox"`
+	OrbitClass                OrbitClass `json:"orbit_class"`
+}
+
+type OrbitClass struct {
+	Type        string `json:"orbit_class_type"`
+	Description string `json:"orbit_class_description"`
+	Range       string `json:"orbit_class_range"`
+}
+
+// NasaAsteroidBrowse is one page of the NeoWs browse endpoint.
+type NasaAsteroidBrowse struct {
+	Page struct {
+		Size          int `json:"size"`
+		TotalElements int `json:"total_elements"`
+		TotalPages    int `json:"total_pages"`
+		Number        int `json:"number"`
+	} `json:"page"`
+	NearEarthObjects []NasaAsteroidObject `json:"near_earth_objects"`
+}
+
+type EstimatedDiameter struct {
+	Kilometers DiameterRange `json:"kilometers"`
+	Meters     DiameterRange `json:"meters"`
+	Miles      DiameterRange `json:"miles"`
+	Feet       DiameterRange `json:"feet"`
+}
+
+type DiameterRange struct {
+	Min float64 `json:"estimated_diameter_min"`
+	Max float64 `json:"estimated_diameter_max"`
+}
+
+// CloseApproach is one pass of an object by an orbiting body. NeoWs sends
+// the velocity and distance figures as strings.
+type CloseApproach struct {
+	Date             string           `json:"close_approach_date"`
+	DateFull         string           `json:"close_approach_date_full"`
+	Epoch            int64            `json:"epoch_date_close_approach"`
+	RelativeVelocity RelativeVelocity `json:"relative_velocity"`
+	MissDistance     MissDistance     `json:"miss_distance"`
+	OrbitingBody     string           `json:"orbiting_body"`
+}
+
+type RelativeVelocity struct {
+	KilometersPerSecond float64 `json:"kilometers_per_second,string"`
+	KilometersPerHour   float64 `json:"kilometers_per_hour,string"`
+	MilesPerHour        float64 `json:"miles_per_hour,string"`
+}
+
+type MissDistance struct {
+	Astronomical float64 `json:"astronomical,string"`
+	Lunar        float64 `json:"lunar,string"`
+	Kilometers   float64 `json:"kilometers,string"`
+	Miles        float64 `json:"miles,string"`
+}
+
+// Time returns the moment of closest approach.
+func (a CloseApproach) Time() time.Time {
+	if a.Epoch != 0 {
+		return time.UnixMilli(a.Epoch).UTC()
+	}
+	t, _ := time.Parse(time.DateOnly, a.Date)
+	return t
+}
+
+// EarthApproaches splits the object's Earth approaches into those before and
+// after now, both in date order.
+func (a NasaAsteroidObject) EarthApproaches(now time.Time) (past, future []CloseApproach) {
+	for _, approach := range a.CloseApproaches {
+		if approach.OrbitingBody != "Earth" {
+			continue
+		}
+		if approach.Time().Before(now) {
+			past = append(past, approach)
+		} else {
+			future = append(future, approach)
+		}
+	}
+	byTime := func(a, b CloseApproach) int { return a.Time().Compare(b.Time()) }
+	slices.SortStableFunc(past, byTime)
+	slices.SortStableFunc(future, byTime)
+	return past, future
+}
+
+// AsteroidApproach pairs an object with one of its close approaches.
+type AsteroidApproach struct {
+	Asteroid NasaAsteroidObject
+	Approach CloseApproach
+}
+
+// Approaches returns every Earth approach in the feed, each paired with the
+// object making it, in date order.
+func (f NasaAsteroid) Approaches() []AsteroidApproach {
+	days := make([]string, 0, len(f.NearEarthObjects))
+	for day := range f.NearEarthObjects {
+		days = append(days, day)
+	}
+	slices.Sort(days)
+
+	var approaches []AsteroidApproach
+	for _, day := range days {
+		for _, asteroid := range f.NearEarthObjects[day] {
+			for _, approach := range asteroid.CloseApproaches {
+				if approach.Date == day && approach.OrbitingBody == "Earth" {
+					approaches = append(approaches, AsteroidApproach{Asteroid: asteroid, Approach: approach})
+				}
+			}
+		}
+	}
+	return approaches
+}
+
+// ClosestApproaches returns the Earth approaches on date listed in the feed,
+// nearest first.
+func (f NasaAsteroid) ClosestApproaches(date time.Time) []AsteroidApproach {
+	day := date.Format(time.DateOnly)
+	var approaches []AsteroidApproach
+	for _, approach := range f.Approaches() {
+		if approach.Approach.Date == day {
+			approaches = append(approaches, approach)
+		}
+	}
+	slices.SortFunc(approaches, func(a, b AsteroidApproach) int {
+		return cmp.Compare(a.Approach.MissDistance.Kilometers, b.Approach.MissDistance.Kilometers)
+	})
+	return approaches
+}
@@ -0,0 +1,135 @@
+package model
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const neoWsFeed = `{
+  "element_count": 3,
+  "near_earth_objects": {
+    "2024-01-02": [
+      {
+        "id": "3542519",
+        "neo_reference_id": "3542519",
+        "name": "(2010 PK9)",
+        "absolute_magnitude_h": 21.9,
+        "estimated_diameter": {
+          "kilometers": {"estimated_diameter_min": 0.1011, "estimated_diameter_max": 0.2261},
+          "meters": {"estimated_diameter_min": 101.1, "estimated_diameter_max": 226.1}
+        },
+        "is_potentially_hazardous_asteroid": true,
+        "close_approach_data": [
+          {
+            "close_approach_date": "2024-01-02",
+            "close_approach_date_full": "2024-Jan-02 07:13",
+            "epoch_date_close_approach": 1704179580000,
+            "relative_velocity": {"kilometers_per_second": "14.2838488", "kilometers_per_hour": "51421.8556", "miles_per_hour": "31951.3"},
+            "miss_distance": {"astronomical": "0.0431", "lunar": "16.77", "kilometers": "6447719.1", "miles": "4006442.2"},
+            "orbiting_body": "Earth"
+          }
+        ]
+      },
+      {
+        "id": "54088823",
+        "name": "(2020 WZ)",
+        "estimated_diameter": {"meters": {"estimated_diameter_min": 10.0, "estimated_diameter_max": 22.4}},
+        "close_approach_data": [
+          {
+            "close_approach_date": "2024-01-02",
+            "relative_velocity": {"kilometers_per_second": "8.1"},
+            "miss_distance": {"kilometers": "384400.0"},
+            "orbiting_body": "Earth"
+          },
+          {
+            "close_approach_date": "2024-01-02",
+            "miss_distance": {"kilometers": "1000.0"},
+            "orbiting_body": "Mars"
+          }
+        ]
+      }
+    ],
+    "2024-01-03": [
+      {
+        "id": "1",
+        "name": "(next day)",
+        "close_approach_data": [{"close_approach_date": "2024-01-03", "miss_distance": {"kilometers": "1.0"}, "orbiting_body": "Earth"}]
+      }
+    ]
+  }
+}`
+
+func TestNasaAsteroidUnmarshalJSON(t *testing.T) {
+	var feed NasaAsteroid
+	require.NoError(t, json.Unmarshal([]byte(neoWsFeed), &feed))
+
+	asteroid := feed.NearEarthObjects["2024-01-02"][0]
+	assert.Equal(t, "3542519", asteroid.NEOReferenceID)
+	assert.Equal(t, "(2010 PK9)", asteroid.Name)
+	assert.Equal(t, 21.9, asteroid.AbsoluteMagnitude)
+	assert.Equal(t, 226.1, asteroid.Diameter.Meters.Max)
+	assert.Equal(t, 0.1011, asteroid.Diameter.Kilometers.Min)
+	assert.True(t, asteroid.Hazardous)
+
+	require.Len(t, asteroid.CloseApproaches, 1)
+	approach := asteroid.CloseApproaches[0]
+	assert.Equal(t, 14.2838488, approach.RelativeVelocity.KilometersPerSecond)
+	assert.Equal(t, 6447719.1, approach.MissDistance.Kilometers)
+	assert.Equal(t, 16.77, approach.MissDistance.Lunar)
+	assert.Equal(t, "Earth", approach.OrbitingBody)
+	assert.Equal(t, time.Date(2024, 1, 2, 7, 13, 0, 0, time.UTC), approach.Time())
+}
+
+func TestClosestApproaches(t *testing.T) {
+	var feed NasaAsteroid
+	require.NoError(t, json.Unmarshal([]byte(neoWsFeed), &feed))
+
+	approaches := feed.ClosestApproaches(time.Date(2024, 1, 2, 18, 30, 0, 0, time.UTC))
+
+	require.Len(t, approaches, 2)
+	assert.Equal(t, "(2020 WZ)", approaches[0].Asteroid.Name)
+	assert.Equal(t, 384400.0, approaches[0].Approach.MissDistance.Kilometers)
+	assert.Equal(t, "(2010 PK9)", approaches[1].Asteroid.Name)
+
+	assert.Empty(t, feed.ClosestApproaches(time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestEarthApproaches(t *testing.T) {
+	var asteroid NasaAsteroidObject
+	require.NoError(t, json.Unmarshal([]byte(`{
+	  "id": "2099942",
+	  "name": "99942 Apophis (2004 MN4)",
+	  "orbital_data": {
+	    "orbit_id": "221",
+	    "data_arc_in_days": 6330,
+	    "eccentricity": ".1911953048308701",
+	    "semi_major_axis": ".9223803069596664",
+	    "inclination": "3.336725450828931",
+	    "orbital_period": "323.5736449883173",
+	    "orbit_class": {"orbit_class_type": "ATE", "orbit_class_description": "Near-Earth asteroid orbits similar to that of 2062 Aten"}
+	  },
+	  "close_approach_data": [
+	    {"close_approach_date": "2029-04-13", "epoch_date_close_approach": 1870725060000, "orbiting_body": "Earth"},
+	    {"close_approach_date": "2013-01-09", "epoch_date_close_approach": 1357751700000, "orbiting_body": "Earth"},
+	    {"close_approach_date": "2021-03-06", "epoch_date_close_approach": 1615052460000, "orbiting_body": "Earth"},
+	    {"close_approach_date": "2023-01-01", "orbiting_body": "Venus"}
+	  ]
+	}`), &asteroid))
+
+	require.NotNil(t, asteroid.OrbitalData)
+	assert.InDelta(t, 0.9224, asteroid.OrbitalData.SemiMajorAxis, 1e-4)
+	assert.InDelta(t, 0.1912, asteroid.OrbitalData.Eccentricity, 1e-4)
+	assert.Equal(t, 6330, asteroid.OrbitalData.DataArcInDays)
+	assert.Equal(t, "ATE", asteroid.OrbitalData.OrbitClass.Type)
+
+	past, future := asteroid.EarthApproaches(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	require.Len(t, past, 2)
+	assert.Equal(t, "2013-01-09", past[0].Date)
+	assert.Equal(t, "2021-03-06", past[1].Date)
+	require.Len(t, future, 1)
+	assert.Equal(t, "2029-04-13", future[0].Date)
+}
@@ -0,0 +1,123 @@
+package model
+
+import "time"
+
+// Payload is a SpaceX v4 payload. Orbital parameters are nil when unknown.
+type Payload struct {
+	ID              string   `json:"id"`
+	Name            string   `json:"name"`
+	Type            string   `json:"type"`
+	Reused          bool     `json:"reused"`
+	LaunchID        string   `json:"launch"`
+	Customers       []string `json:"customers"`
+	NoradIDs        []int    `json:"norad_ids"`
+	Nationalities   []string `json:"nationalities"`
+	Manufacturers   []string `json:"manufacturers"`
+	MassKg          *float64 `json:"mass_kg"`
+	MassLbs         *float64 `json:"mass_lbs"`
+	Orbit           string   `json:"orbit"`
+	ReferenceSystem string   `json:"reference_system"`
+	Regime          string   `json:"regime"`
+
+	Longitude       *float64   `json:"longitude"`
+	SemiMajorAxisKm *float64   `json:"semi_major_axis_km"`
+	Eccentricity    *float64   `json:"eccentricity"`
+	PeriapsisKm     *float64   `json:"periapsis_km"`
+	ApoapsisKm      *float64   `json:"apoapsis_km"`
+	InclinationDeg  *float64   `json:"inclination_deg"`
+	PeriodMin       *float64   `json:"period_min"`
+	LifespanYears   *float64   `json:"lifespan_years"`
+	Epoch           *time.Time `json:"epoch"`
+	MeanMotion      *float64   `json:"mean_motion"`
+	RAAN            *float64   `json:"raan"`
+	ArgOfPericenter *float64   `json:"arg_of_pericenter"`
+	MeanAnomaly     *float64   `json:"mean_anomaly"`
+
+	Dragon PayloadDragon `json:"dragon"`
+}
+
+// PayloadDragon describes a payload flown on a Dragon capsule.
+type PayloadDragon struct {
+	CapsuleID       string   `json:"capsule"`
+	MassReturnedKg  *float64 `json:"mass_returned_kg"`
+	MassReturnedLbs *float64 `json:"mass_returned_lbs"`
+	FlightTimeSec   *int     `json:"flight_time_sec"`
+	Manifest        string   `json:"manifest"`
+	WaterLanding    *bool    `json:"water_landing"`
+	LandLanding     *bool    `json:"land_landing"`
+}
+
+// Core is a first stage booster.
+type Core struct {
+	ID           string   `json:"id"`
+	Serial       string   `json:"serial"`
+	Block        *int     `json:"block"`
+	Status       string   `json:"status"`
+	ReuseCount   int      `json:"reuse_count"`
+	RTLSAttempts int      `json:"rtls_attempts"`
+	RTLSLandings int      `json:"rtls_landings"`
+	ASDSAttempts int      `json:"asds_attempts"`
+	ASDSLandings int      `json:"asds_landings"`
+	LastUpdate   string   `json:"last_update"`
+	Launches     []string `json:"launches"`
+}
+
+// Capsule is a Dragon capsule.
+type Capsule struct {
+	ID            string   `json:"id"`
+	Serial        string   `json:"serial"`
+	Status        string   `json:"status"`
+	Type          string   `json:"type"`
+	DragonID      string   `json:"dragon"`
+	ReuseCount    int      `json:"reuse_count"`
+	WaterLandings int      `json:"water_landings"`
+	LandLandings  int      `json:"land_landings"`
+	LastUpdate    string   `json:"last_update"`
+	Launches      []string `json:"launches"`
+}
+
+// Ship is a recovery, support or drone ship.
+type Ship struct {
+	ID            string     `json:"id"`
+	Name          string     `json:"name"`
+	LegacyID      string     `json:"legacy_id"`
+	Model         string     `json:"model"`
+	Type          string     `json:"type"`
+	Roles         []string   `json:"roles"`
+	Active        bool       `json:"active"`
+	IMO           *int       `json:"imo"`
+	MMSI          *int       `json:"mmsi"`
+	ABS           *int       `json:"abs"`
+	Class         *int       `json:"class"`
+	MassKg        *float64   `json:"mass_kg"`
+	MassLbs       *float64   `json:"mass_lbs"`
+	YearBuilt     *int       `json:"year_built"`
+	HomePort      string     `json:"home_port"`
+	Status        string     `json:"status"`
+	SpeedKn       *float64   `json:"speed_kn"`
+	CourseDeg     *float64   `json:"course_deg"`
+	Latitude      *float64   `json:"latitude"`
+	Longitude     *float64   `json:"longitude"`
+	LastAISUpdate *time.Time `json:"last_ais_update"`
+	Link          string     `json:"link"`
+	Image         string     `json:"image"`
+	Launches      []string   `json:"launches"`
+}
+
+// Landpad is a landing zone (RTLS) or drone ship (ASDS) landing site.
+type Landpad struct {
+	ID               string   `json:"id"`
+	Name             string   `json:"name"`
+	FullName         string   `json:"full_name"`
+	Status           string   `json:"status"`
+	Type             string   `json:"type"`
+	Locality         string   `json:"locality"`
+	Region           string   `json:"region"`
+	Latitude         float64  `json:"latitude"`
+	Longitude        float64  `json:"longitude"`
+	LandingAttempts  int      `json:"landing_attempts"`
+	LandingSuccesses int      `json:"landing_successes"`
+	Wikipedia        string   `json:"wikipedia"`
+	Details          string   `json:"details"`
+	Launches         []string `json:"launches"`
+}
@@ -2,19 +2,124 @@ package model
 
 import (
 	"fmt"
+	"maps"
+	"net/url"
+	"slices"
+	"strings"
 	"time"
 )
 
+const (
+	DefaultSpaceXBaseURL = "https://api.spacexdata.com/v4"
+	DefaultNASABaseURL   = "https://api.nasa.gov"
+	DefaultEONETBaseURL  = "https://eonet.gsfc.nasa.gov/api/v3"
+	DefaultPOWERBaseURL  = "https://power.larc.nasa.gov/api"
+	DefaultLL2BaseURL    = "https://ll.thespacedevs.com/2.2.0"
+)
+
+// Default windows for the natural events and space weather reported around
+// a launch.
+const (
+	DefaultHazardRadiusKm         = 200
+	DefaultHazardWindowDays       = 2
+	DefaultSpaceWeatherWindowDays = 1
+)
+
+// Launch Library 2 rate limits in requests per second. Anonymous clients
+// get 15 requests an hour, which LL2Burst lets a run spend at once; token
+// holders get a higher tier, which ll2_rate_limit should be set to match.
+const (
+	DefaultLL2RateLimit      = 15.0 / 3600
+	DefaultLL2TokenRateLimit = 1
+	LL2Burst                 = 15
+)
+
+// Launch providers selectable with Config.Provider.
+const (
+	ProviderSpaceX        = "spacex"
+	ProviderLaunchLibrary = "ll2"
+	ProviderAll           = "all"
+)
+
+// ProviderConfig configures a launch provider beyond the built-in ones,
+// under providers.<name> in the config file.
+type ProviderConfig struct {
+	// Type names the provider registered with api.RegisterProvider. It
+	// defaults to "exec" when Command is set and to the provider's name
+	// otherwise.
+	Type string `mapstructure:"type"`
+	// Command and Args run an external provider speaking the JSON-over-stdio
+	// protocol.
+	Command string   `mapstructure:"command"`
+	Args    []string `mapstructure:"args"`
+	// Timeout bounds each call to the provider; it defaults to Config.Timeout.
+	Timeout time.Duration `mapstructure:"timeout"`
+	// Settings are passed to the provider as is.
+	Settings map[string]any `mapstructure:"settings"`
+}
+
 type Config struct {
 	NASAAPIKey string        `validate:"required"`
 	Timeout    time.Duration `validate:"required,min=1s"`
 	Retries    int           `validate:"required,min=1,max=10"`
 	BaseDelay  time.Duration `validate:"required,min=100ms"`
 	MaxDelay   time.Duration `validate:"required,min=1s"`
+	// Backoff is one of "linear", "full-jitter" or "decorrelated-jitter".
+	Backoff string `validate:"oneof=linear full-jitter decorrelated-jitter"`
+
+	// LL2APIToken is optional; without it Launch Library 2 allows 15
+	// requests an hour.
+	LL2APIToken string
+
+	SpaceXBaseURL string `validate:"required,url"`
+	NASABaseURL   string `validate:"required,url"`
+	EONETBaseURL  string `validate:"required,url"`
+	POWERBaseURL  string `validate:"required,url"`
+	LL2BaseURL    string `validate:"required,url"`
+
+	// Provider selects where launches come from: spacex, ll2, all or a
+	// comma-separated list of provider names, in order of preference.
+	Provider  string `validate:"required"`
+	Providers map[string]ProviderConfig
+
+	CacheDir     string
+	NoCache      bool
+	RefreshCache bool
+	ReferenceTTL time.Duration `validate:"min=0"`
+	LaunchesTTL  time.Duration `validate:"min=0"`
+	NASATTL      time.Duration `validate:"min=0"`
+
+	// RecordDir saves all API traffic as fixtures; ReplayDir serves it back
+	// without touching the network.
+	RecordDir string
+	ReplayDir string
+
+	// Client-side request rates per provider, in requests per second.
+	SpaceXRateLimit float64 `validate:"gt=0"`
+	NASARateLimit   float64 `validate:"gt=0"`
+	EONETRateLimit  float64 `validate:"gt=0"`
+	POWERRateLimit  float64 `validate:"gt=0"`
+	LL2RateLimit    float64 `validate:"gt=0"`
+
+	// A host's circuit opens after BreakerThreshold consecutive failures and
+	// stays open for BreakerCooldown.
+	BreakerThreshold int           `validate:"min=1"`
+	BreakerCooldown  time.Duration `validate:"min=1s"`
+
+	// Natural events are reported for a launch when they come within
+	// HazardRadiusKm of the pad up to HazardWindowDays either side of the
+	// launch date.
+	HazardRadiusKm   float64 `validate:"gt=0"`
+	HazardWindowDays int     `validate:"min=0"`
+
+	// Space weather is reported from SpaceWeatherWindowDays before a launch
+	// to as many days after it.
+	SpaceWeatherWindowDays int `validate:"min=0"`
 }
 
 func (c *Config) Validate() error {
-	if c.NASAAPIKey == "" {
+	// Replayed runs never reach NASA, so they can go without a key.
+	if c.NASAAPIKey == "" && c.ReplayDir == "" {
 		return fmt.Errorf("NASA API key is required")
 	}
 	if c.Timeout < time.Second {
@@ -29,14 +134,130 @@ func (c *Config) Validate() error {
 	if c.MaxDelay < time.Second {
 		return fmt.Errorf("max delay must be at least 1 second")
 	}
+	switch c.Backoff {
+	case "linear", "full-jitter", "decorrelated-jitter":
+	default:
+		return fmt.Errorf("backoff must be one of linear, full-jitter or decorrelated-jitter")
+	}
+	if c.ReferenceTTL < 0 || c.LaunchesTTL < 0 || c.NASATTL < 0 {
+		return fmt.Errorf("cache TTLs must not be negative")
+	}
+	if c.SpaceXRateLimit <= 0 || c.NASARateLimit <= 0 || c.EONETRateLimit <= 0 || c.POWERRateLimit <= 0 || c.LL2RateLimit <= 0 {
+		return fmt.Errorf("rate limits must be positive")
+	}
+	if c.BreakerThreshold < 1 {
+		return fmt.Errorf("breaker threshold must be at least 1")
+	}
+	if c.BreakerCooldown < time.Second {
+		return fmt.Errorf("breaker cooldown must be at least 1 second")
+	}
+	if c.HazardRadiusKm <= 0 {
+		return fmt.Errorf("hazard radius must be positive")
+	}
+	if c.HazardWindowDays < 0 {
+		return fmt.Errorf("hazard window must not be negative")
+	}
+	if c.SpaceWeatherWindowDays < 0 {
+		return fmt.Errorf("space weather window must not be negative")
+	}
+	names := c.ProviderNames()
+	if len(names) == 0 || slices.Contains(names, ProviderAll) {
+		return fmt.Errorf("provider must be spacex, ll2, all or a comma-separated list of providers")
+	}
+	for name, provider := range c.Providers {
+		if name == ProviderSpaceX || name == ProviderLaunchLibrary || name == ProviderAll {
+			return fmt.Errorf("provider name %q is reserved", name)
+		}
+		if provider.Timeout < 0 {
+			return fmt.Errorf("provider %s: timeout must not be negative", name)
+		}
+	}
+	if c.RecordDir != "" && c.ReplayDir != "" {
+		return fmt.Errorf("record and replay modes cannot be combined")
+	}
+	for name, baseURL := range map[string]string{
+		"SpaceX": c.SpaceXBaseURL,
+		"NASA":   c.NASABaseURL,
+		"EONET":  c.EONETBaseURL,
+		"POWER":  c.POWERBaseURL,
+		"LL2":    c.LL2BaseURL,
+	} {
+		if err := validateBaseURL(baseURL); err != nil {
+			return fmt.Errorf("invalid %s base URL: %w", name, err)
+		}
+	}
 	return nil
 }
 
+// ProviderNames lists the selected providers in order of preference. "all"
+// selects the built-in providers followed by every configured one.
+func (c *Config) ProviderNames() []string {
+	if strings.TrimSpace(c.Provider) == ProviderAll {
+		names := []string{ProviderSpaceX, ProviderLaunchLibrary}
+		for _, name := range slices.Sorted(maps.Keys(c.Providers)) {
+			if !slices.Contains(names, name) {
+				names = append(names, name)
+			}
+		}
+		return names
+	}
+
+	var names []string
+	for _, name := range strings.Split(c.Provider, ",") {
+		name = strings.TrimSpace(name)
+		if name != "" && !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
 func DefaultConfig() *Config {
 	return &Config{
 		Timeout:   30 * time.Second,
 		Retries:   5,
 		BaseDelay: 200 * time.Millisecond,
 		MaxDelay:  5 * time.Second,
+		Backoff:   "linear",
+
+		SpaceXBaseURL: DefaultSpaceXBaseURL,
+		NASABaseURL:   DefaultNASABaseURL,
+		EONETBaseURL:  DefaultEONETBaseURL,
+		POWERBaseURL:  DefaultPOWERBaseURL,
+		LL2BaseURL:    DefaultLL2BaseURL,
+
+		Provider: ProviderSpaceX,
+
+		ReferenceTTL: 24 * time.Hour,
+		LaunchesTTL:  time.Hour,
+		NASATTL:      6 * time.Hour,
+
+		SpaceXRateLimit: 10,
+		NASARateLimit:   5,
+		EONETRateLimit:  5,
+		POWERRateLimit:  5,
+		LL2RateLimit:    DefaultLL2RateLimit,
+
+		BreakerThreshold: 5,
+		BreakerCooldown:  30 * time.Second,
+
+		HazardRadiusKm:   DefaultHazardRadiusKm,
+		HazardWindowDays: DefaultHazardWindowDays,
+
+		SpaceWeatherWindowDays: DefaultSpaceWeatherWindowDays,
 	}
 }
+
+func validateBaseURL(baseURL string) error {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("%q must use http or https", baseURL)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("%q has no host", baseURL)
+	}
+	return nil
+}
@@ -0,0 +1,182 @@
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// donkiTimeLayouts are the timestamp formats DONKI uses; most omit seconds.
+var donkiTimeLayouts = []string{"2006-01-02T15:04Z", time.RFC3339, "2006-01-02T15:04:05Z", time.DateOnly}
+
+// DONKITime is a DONKI timestamp. Null or empty values decode to the zero
+// time.
+type DONKITime struct {
+	time.Time
+}
+
+func (t *DONKITime) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		t.Time = time.Time{}
+		return nil
+	}
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	if value == "" {
+		t.Time = time.Time{}
+		return nil
+	}
+	for _, layout := range donkiTimeLayouts {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			t.Time = parsed
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid DONKI time %q", value)
+}
+
+func (t DONKITime) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(t.UTC().Format("2006-01-02T15:04Z"))
+}
+
+type DONKIInstrument struct {
+	DisplayName string `json:"displayName"`
+}
+
+type DONKILinkedEvent struct {
+	ActivityID string `json:"activityID"`
+}
+
+// SolarFlare is a DONKI FLR event.
+type SolarFlare struct {
+	ID              string             `json:"flrID"`
+	Instruments     []DONKIInstrument  `json:"instruments"`
+	BeginTime       DONKITime          `json:"beginTime"`
+	PeakTime        DONKITime          `json:"peakTime"`
+	EndTime         DONKITime          `json:"endTime"`
+	ClassType       string             `json:"classType"`
+	SourceLocation  string             `json:"sourceLocation"`
+	ActiveRegionNum *int               `json:"activeRegionNum"`
+	Link            string             `json:"link"`
+	LinkedEvents    []DONKILinkedEvent `json:"linkedEvents"`
+}
+
+// CME is a DONKI coronal mass ejection.
+type CME struct {
+	ActivityID      string             `json:"activityID"`
+	Catalog         string             `json:"catalog"`
+	StartTime       DONKITime          `json:"startTime"`
+	SourceLocation  string             `json:"sourceLocation"`
+	ActiveRegionNum *int               `json:"activeRegionNum"`
+	Note            string             `json:"note"`
+	Instruments     []DONKIInstrument  `json:"instruments"`
+	Analyses        []CMEAnalysis      `json:"cmeAnalyses"`
+	Link            string             `json:"link"`
+	LinkedEvents    []DONKILinkedEvent `json:"linkedEvents"`
+}
+
+type CMEAnalysis struct {
+	Time21_5       DONKITime `json:"time21_5"`
+	Latitude       *float64  `json:"latitude"`
+	Longitude      *float64  `json:"longitude"`
+	HalfAngle      *float64  `json:"halfAngle"`
+	Speed          *float64  `json:"speed"`
+	Type           string    `json:"type"`
+	IsMostAccurate bool      `json:"isMostAccurate"`
+	Note           string    `json:"note"`
+}
+
+// Speed returns the CME speed in km/s from its most accurate analysis.
+func (c CME) Speed() (float64, bool) {
+	var speed *float64
+	for _, analysis := range c.Analyses {
+		if analysis.Speed != nil && (speed == nil || analysis.IsMostAccurate) {
+			speed = analysis.Speed
+		}
+	}
+	if speed == nil {
+		return 0, false
+	}
+	return *speed, true
+}
+
+// GeomagneticStorm is a DONKI GST event.
+type GeomagneticStorm struct {
+	ID           string             `json:"gstID"`
+	StartTime    DONKITime          `json:"startTime"`
+	KpIndex      []KpIndex          `json:"allKpIndex"`
+	Link         string             `json:"link"`
+	LinkedEvents []DONKILinkedEvent `json:"linkedEvents"`
+}
+
+type KpIndex struct {
+	ObservedTime DONKITime `json:"observedTime"`
+	KpIndex      float64   `json:"kpIndex"`
+	Source       string    `json:"source"`
+}
+
+// MaxKp returns the highest Kp index observed during the storm.
+func (g GeomagneticStorm) MaxKp() float64 {
+	maxKp := 0.0
+	for _, kp := range g.KpIndex {
+		maxKp = max(maxKp, kp.KpIndex)
+	}
+	return maxKp
+}
+
+// SolarEnergeticParticle is a DONKI SEP event.
+type SolarEnergeticParticle struct {
+	ID           string             `json:"sepID"`
+	EventTime    DONKITime          `json:"eventTime"`
+	Instruments  []DONKIInstrument  `json:"instruments"`
+	Link         string             `json:"link"`
+	LinkedEvents []DONKILinkedEvent `json:"linkedEvents"`
+}
+
+// SpaceWeather collects the DONKI events over a period.
+type SpaceWeather struct {
+	Flares    []SolarFlare
+	CMEs      []CME
+	Storms    []GeomagneticStorm
+	Particles []SolarEnergeticParticle
+}
+
+func (w SpaceWeather) Empty() bool {
+	return len(w.Flares) == 0 && len(w.CMEs) == 0 && len(w.Storms) == 0 && len(w.Particles) == 0
+}
+
+// StrongestFlare returns the flare with the highest class, e.g. X2.0 over
+// M9.9.
+func (w SpaceWeather) StrongestFlare() (SolarFlare, bool) {
+	var strongest SolarFlare
+	found := false
+	for _, flare := range w.Flares {
+		if !found || FlareIntensity(flare.ClassType) > FlareIntensity(strongest.ClassType) {
+			strongest = flare
+			found = true
+		}
+	}
+	return strongest, found
+}
+
+// FlareIntensity converts a GOES class such as "M2.5" to peak X-ray flux in
+// W/m². Unknown classes yield 0.
+func FlareIntensity(class string) float64 {
+	if class == "" {
+		return 0
+	}
+	scale := map[byte]float64{'A': 1e-8, 'B': 1e-7, 'C': 1e-6, 'M': 1e-5, 'X': 1e-4}[class[0]]
+	magnitude := 1.0
+	if len(class) > 1 {
+		if _, err := fmt.Sscanf(class[1:], "%g", &magnitude); err != nil {
+			magnitude = 1
+		}
+	}
+	return scale * magnitude
+}
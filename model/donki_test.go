@@ -0,0 +1,52 @@
+package model
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDONKITime(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected time.Time
+	}{
+		{name: "minutes", input: `"2024-05-14T16:46Z"`, expected: time.Date(2024, 5, 14, 16, 46, 0, 0, time.UTC)},
+		{name: "seconds", input: `"2024-05-14T16:46:30Z"`, expected: time.Date(2024, 5, 14, 16, 46, 30, 0, time.UTC)},
+		{name: "null", input: `null`},
+		{name: "empty", input: `""`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var decoded DONKITime
+			require.NoError(t, json.Unmarshal([]byte(tt.input), &decoded))
+			assert.True(t, tt.expected.Equal(decoded.Time))
+		})
+	}
+
+	var decoded DONKITime
+	assert.Error(t, json.Unmarshal([]byte(`"yesterday"`), &decoded))
+
+	data, err := json.Marshal(DONKITime{Time: time.Date(2024, 5, 14, 16, 46, 0, 0, time.UTC)})
+	require.NoError(t, err)
+	assert.Equal(t, `"2024-05-14T16:46Z"`, string(data))
+}
+
+func TestStrongestFlare(t *testing.T) {
+	assert.InDelta(t, 2.5e-5, FlareIntensity("M2.5"), 1e-12)
+	assert.InDelta(t, 1e-4, FlareIntensity("X"), 1e-12)
+	assert.Zero(t, FlareIntensity(""))
+
+	weather := SpaceWeather{Flares: []SolarFlare{{ClassType: "M9.9"}, {ClassType: "X1.0"}, {ClassType: "C3.2"}}}
+	strongest, ok := weather.StrongestFlare()
+	require.True(t, ok)
+	assert.Equal(t, "X1.0", strongest.ClassType)
+
+	_, ok = SpaceWeather{}.StrongestFlare()
+	assert.False(t, ok)
+}
@@ -0,0 +1,118 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// NasaEarthEvent is a natural event tracked by EONET v3.
+type NasaEarthEvent struct {
+	ID          string          `json:"id"`
+	Title       string          `json:"title"`
+	Description string          `json:"description"`
+	Link        string          `json:"link"`
+	Closed      *time.Time      `json:"closed"`
+	Categories  []EventCategory `json:"categories"`
+	Sources     []EventSource   `json:"sources"`
+	Geometry    []EventGeometry `json:"geometry"`
+}
+
+type NasaEarth struct {
+	Events []NasaEarthEvent `json:"events"`
+}
+
+type EventCategory struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+}
+
+type EventSource struct {
+	ID  string `json:"id"`
+	URL string `json:"url"`
+}
+
+const (
+	GeometryPoint   = "Point"
+	GeometryPolygon = "Polygon"
+)
+
+// EventGeometry is one observation of an event. Depending on Type either
+// Point or Polygon is set; positions are [longitude, latitude].
+type EventGeometry struct {
+	Date           time.Time `json:"date"`
+	Type           string    `json:"type"`
+	MagnitudeValue *float64  `json:"magnitudeValue"`
+	MagnitudeUnit  string    `json:"magnitudeUnit"`
+	Point          []float64 `json:"-"`
+	// Polygon holds the linear rings of the polygon, outer ring first.
+	Polygon [][][]float64 `json:"-"`
+}
+
+// IsOpen reports whether the event is still ongoing.
+func (e NasaEarthEvent) IsOpen() bool {
+	return e.Closed == nil
+}
+
+// UnmarshalJSON decodes the GeoJSON coordinates according to the geometry
+// type.
+func (g *EventGeometry) UnmarshalJSON(data []byte) error {
+	type geometryAlias EventGeometry
+	aux := struct {
+		*geometryAlias
+		Coordinates json.RawMessage `json:"coordinates"`
+	}{geometryAlias: (*geometryAlias)(g)}
+
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	g.Point = nil
+	g.Polygon = nil
+	if len(aux.Coordinates) == 0 {
+		return nil
+	}
+	switch g.Type {
+	case GeometryPoint:
+		if err := json.Unmarshal(aux.Coordinates, &g.Point); err != nil {
+			return fmt.Errorf("invalid point coordinates: %w", err)
+		}
+	case GeometryPolygon:
+		if err := json.Unmarshal(aux.Coordinates, &g.Polygon); err != nil {
+			return fmt.Errorf("invalid polygon coordinates: %w", err)
+		}
+	}
+	return nil
+}
+
+func (g EventGeometry) MarshalJSON() ([]byte, error) {
+	type geometryAlias EventGeometry
+	var coordinates any
+	switch g.Type {
+	case GeometryPoint:
+		coordinates = g.Point
+	case GeometryPolygon:
+		coordinates = g.Polygon
+	}
+	return json.Marshal(struct {
+		geometryAlias
+		Coordinates any `json:"coordinates"`
+	}{geometryAlias: geometryAlias(g), Coordinates: coordinates})
+}
+
+// Positions returns every [longitude, latitude] position of the geometry:
+// the point itself or the vertices of the polygon's rings.
+func (g EventGeometry) Positions() [][]float64 {
+	if len(g.Point) >= 2 {
+		return [][]float64{g.Point}
+	}
+	var positions [][]float64
+	for _, ring := range g.Polygon {
+		for _, position := range ring {
+			if len(position) >= 2 {
+				positions = append(positions, position)
+			}
+		}
+	}
+	return positions
+}
@@ -0,0 +1,73 @@
+package model
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNasaEarthUnmarshalJSON(t *testing.T) {
+	var earth NasaEarth
+	require.NoError(t, json.Unmarshal([]byte(`{
+	  "events": [
+	    {
+	      "id": "EONET_6591",
+	      "title": "Hurricane Helene",
+	      "description": null,
+	      "link": "https://eonet.gsfc.nasa.gov/api/v3/events/EONET_6591",
+	      "closed": "2024-09-28T00:00:00Z",
+	      "categories": [{"id": "severeStorms", "title": "Severe Storms"}],
+	      "sources": [{"id": "JTWC", "url": "https://www.metoc.navy.mil/jtwc/products/al0924.tcw"}],
+	      "geometry": [
+	        {"magnitudeValue": 120.00, "magnitudeUnit": "kts", "date": "2024-09-26T18:00:00Z", "type": "Point", "coordinates": [-84.5, 27.1]}
+	      ]
+	    },
+	    {
+	      "id": "EONET_7000",
+	      "title": "Line Fire",
+	      "closed": null,
+	      "categories": [{"id": "wildfires", "title": "Wildfires"}],
+	      "sources": [{"id": "InciWeb", "url": "https://inciweb.wildfire.gov/"}],
+	      "geometry": [
+	        {"magnitudeValue": null, "magnitudeUnit": null, "date": "2024-09-06T00:00:00Z", "type": "Polygon", "coordinates": [[[-117.2, 34.1], [-117.1, 34.1], [-117.1, 34.2], [-117.2, 34.1]]]}
+	      ]
+	    }
+	  ]
+	}`), &earth))
+
+	require.Len(t, earth.Events, 2)
+
+	storm := earth.Events[0]
+	assert.False(t, storm.IsOpen())
+	assert.Equal(t, time.Date(2024, 9, 28, 0, 0, 0, 0, time.UTC), *storm.Closed)
+	assert.Equal(t, "JTWC", storm.Sources[0].ID)
+	require.Len(t, storm.Geometry, 1)
+	assert.Equal(t, 120.0, *storm.Geometry[0].MagnitudeValue)
+	assert.Equal(t, "kts", storm.Geometry[0].MagnitudeUnit)
+	assert.Equal(t, []float64{-84.5, 27.1}, storm.Geometry[0].Point)
+	assert.Equal(t, [][]float64{{-84.5, 27.1}}, storm.Geometry[0].Positions())
+
+	fire := earth.Events[1]
+	assert.True(t, fire.IsOpen())
+	assert.Nil(t, fire.Geometry[0].MagnitudeValue)
+	require.Len(t, fire.Geometry[0].Polygon, 1)
+	assert.Len(t, fire.Geometry[0].Positions(), 4)
+}
+
+func TestEventGeometryRoundTrip(t *testing.T) {
+	geometry := EventGeometry{
+		Date:    time.Date(2024, 9, 6, 0, 0, 0, 0, time.UTC),
+		Type:    GeometryPolygon,
+		Polygon: [][][]float64{{{1, 2}, {3, 4}, {1, 2}}},
+	}
+
+	data, err := json.Marshal(geometry)
+	require.NoError(t, err)
+
+	var decoded EventGeometry
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, geometry, decoded)
+}
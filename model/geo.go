@@ -0,0 +1,143 @@
+package model
+
+import (
+	"math"
+	"time"
+)
+
+// EarthRadiusKm is the mean radius of the Earth.
+const EarthRadiusKm = 6371.0
+
+// GreatCircleDistance returns the distance in kilometers between two
+// positions given in degrees, using the haversine formula.
+func GreatCircleDistance(lat1, lon1, lat2, lon2 float64) float64 {
+	phi1 := lat1 * math.Pi / 180
+	phi2 := lat2 * math.Pi / 180
+	dPhi := (lat2 - lat1) * math.Pi / 180
+	dLambda := (lon2 - lon1) * math.Pi / 180
+
+	a := math.Sin(dPhi/2)*math.Sin(dPhi/2) + math.Cos(phi1)*math.Cos(phi2)*math.Sin(dLambda/2)*math.Sin(dLambda/2)
+	return 2 * EarthRadiusKm * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+// NearbyEvent is an event together with its closest observed position to a
+// reference point.
+type NearbyEvent struct {
+	Event NasaEarthEvent
+	// Closest is the nearest [longitude, latitude] position.
+	Closest    []float64
+	DistanceKm float64
+	// Date is when the event was observed at Closest.
+	Date time.Time
+}
+
+// Nearest finds the geometry of e closest to the given position among those
+// observed between from and to. Polygons are measured to the nearest point
+// on their edges, and points inside a polygon are at distance 0. It returns
+// false if no geometry falls in the window.
+func (e NasaEarthEvent) Nearest(lat, lon float64, from, to time.Time) (NearbyEvent, bool) {
+	nearest := NearbyEvent{Event: e, DistanceKm: math.Inf(1)}
+	consider := func(distance float64, position []float64, date time.Time) {
+		if distance < nearest.DistanceKm {
+			nearest.Closest = position
+			nearest.DistanceKm = distance
+			nearest.Date = date
+		}
+	}
+
+	for _, geometry := range e.Geometry {
+		if geometry.Date.Before(from) || geometry.Date.After(to) {
+			continue
+		}
+		if geometry.Type != GeometryPolygon {
+			for _, position := range geometry.Positions() {
+				consider(GreatCircleDistance(lat, lon, position[1], position[0]), position, geometry.Date)
+			}
+			continue
+		}
+		if len(geometry.Polygon) > 0 && insideRing(lat, lon, geometry.Polygon[0]) {
+			return NearbyEvent{Event: e, Closest: []float64{lon, lat}, DistanceKm: 0, Date: geometry.Date}, true
+		}
+		for _, ring := range geometry.Polygon {
+			for i := range ring {
+				a, b := ring[i], ring[(i+1)%len(ring)]
+				if len(a) < 2 || len(b) < 2 {
+					continue
+				}
+				distance, closest := segmentDistance(lat, lon, a, b)
+				consider(distance, closest, geometry.Date)
+			}
+		}
+	}
+	return nearest, nearest.Closest != nil
+}
+
+// segmentDistance returns the great-circle distance in kilometers from a
+// position to the shortest arc between the [longitude, latitude] positions a
+// and b, along with the closest point of the arc.
+func segmentDistance(lat, lon float64, a, b []float64) (float64, []float64) {
+	p, va, vb := unitVector(lat, lon), unitVector(a[1], a[0]), unitVector(b[1], b[0])
+	normal := cross(va, vb)
+	if norm := math.Sqrt(dot(normal, normal)); norm > 1e-12 {
+		normal = scale(normal, 1/norm)
+		// Project the position onto the arc's great circle; the foot counts
+		// when it lies between a and b.
+		foot := sub(p, scale(normal, dot(p, normal)))
+		if length := math.Sqrt(dot(foot, foot)); length > 1e-12 {
+			foot = scale(foot, 1/length)
+			if dot(cross(va, foot), normal) >= 0 && dot(cross(foot, vb), normal) >= 0 {
+				footLat, footLon := toDegrees(foot)
+				return GreatCircleDistance(lat, lon, footLat, footLon), []float64{footLon, footLat}
+			}
+		}
+	}
+
+	toA := GreatCircleDistance(lat, lon, a[1], a[0])
+	toB := GreatCircleDistance(lat, lon, b[1], b[0])
+	if toB < toA {
+		return toB, b
+	}
+	return toA, a
+}
+
+func unitVector(lat, lon float64) [3]float64 {
+	phi, lambda := lat*math.Pi/180, lon*math.Pi/180
+	return [3]float64{math.Cos(phi) * math.Cos(lambda), math.Cos(phi) * math.Sin(lambda), math.Sin(phi)}
+}
+
+func toDegrees(v [3]float64) (lat, lon float64) {
+	return math.Asin(math.Max(-1, math.Min(1, v[2]))) * 180 / math.Pi, math.Atan2(v[1], v[0]) * 180 / math.Pi
+}
+
+func dot(a, b [3]float64) float64 {
+	return a[0]*b[0] + a[1]*b[1] + a[2]*b[2]
+}
+
+func cross(a, b [3]float64) [3]float64 {
+	return [3]float64{a[1]*b[2] - a[2]*b[1], a[2]*b[0] - a[0]*b[2], a[0]*b[1] - a[1]*b[0]}
+}
+
+func scale(v [3]float64, k float64) [3]float64 {
+	return [3]float64{v[0] * k, v[1] * k, v[2] * k}
+}
+
+func sub(a, b [3]float64) [3]float64 {
+	return [3]float64{a[0] - b[0], a[1] - b[1], a[2] - b[2]}
+}
+
+// insideRing reports whether the position lies inside the linear ring, by
+// ray casting in longitude/latitude space.
+func insideRing(lat, lon float64, ring [][]float64) bool {
+	inside := false
+	for i, j := 0, len(ring)-1; i < len(ring); j, i = i, i+1 {
+		if len(ring[i]) < 2 || len(ring[j]) < 2 {
+			continue
+		}
+		xi, yi := ring[i][0], ring[i][1]
+		xj, yj := ring[j][0], ring[j][1]
+		if (yi > lat) != (yj > lat) && lon < (xj-xi)*(lat-yi)/(yj-yi)+xi {
+			inside = !inside
+		}
+	}
+	return inside
+}
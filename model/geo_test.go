@@ -0,0 +1,70 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGreatCircleDistance(t *testing.T) {
+	tests := []struct {
+		name       string
+		lat1, lon1 float64
+		lat2, lon2 float64
+		expected   float64
+	}{
+		{name: "same point", lat1: 28.56, lon1: -80.58, lat2: 28.56, lon2: -80.58, expected: 0},
+		{name: "London to Paris", lat1: 51.5074, lon1: -0.1278, lat2: 48.8566, lon2: 2.3522, expected: 343.6},
+		{name: "across the antimeridian", lat1: 0, lon1: 179.5, lat2: 0, lon2: -179.5, expected: 111.2},
+		{name: "pole to pole", lat1: 90, lon1: 0, lat2: -90, lon2: 0, expected: 20015.1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.expected, GreatCircleDistance(tt.lat1, tt.lon1, tt.lat2, tt.lon2), 0.5)
+		})
+	}
+}
+
+func TestNasaEarthEventNearest(t *testing.T) {
+	day := time.Date(2024, 9, 26, 0, 0, 0, 0, time.UTC)
+	storm := NasaEarthEvent{ID: "storm", Geometry: []EventGeometry{
+		{Date: day.AddDate(0, 0, -3), Type: GeometryPoint, Point: []float64{-80.6, 28.6}},
+		{Date: day, Type: GeometryPoint, Point: []float64{-84.5, 27.1}},
+		{Date: day.AddDate(0, 0, 1), Type: GeometryPoint, Point: []float64{-83.0, 30.0}},
+	}}
+	fire := NasaEarthEvent{ID: "fire", Geometry: []EventGeometry{
+		{Date: day, Type: GeometryPolygon, Polygon: [][][]float64{{{-81, 28}, {-80, 28}, {-80, 29}, {-81, 29}, {-81, 28}}}},
+	}}
+
+	near, ok := storm.Nearest(28.6, -80.6, day.AddDate(0, 0, -1), day.AddDate(0, 0, 2))
+	assert.True(t, ok)
+	assert.Equal(t, []float64{-83.0, 30.0}, near.Closest)
+	assert.Equal(t, day.AddDate(0, 0, 1), near.Date)
+	assert.InDelta(t, 275, near.DistanceKm, 5)
+
+	near, ok = storm.Nearest(28.6, -80.6, day.AddDate(0, 0, -5), day)
+	assert.True(t, ok)
+	assert.Zero(t, near.DistanceKm)
+
+	_, ok = storm.Nearest(28.6, -80.6, day.AddDate(0, 0, 5), day.AddDate(0, 0, 6))
+	assert.False(t, ok)
+
+	near, ok = fire.Nearest(28.6, -80.6, day, day)
+	assert.True(t, ok)
+	assert.Zero(t, near.DistanceKm)
+	assert.Equal(t, []float64{-80.6, 28.6}, near.Closest)
+
+	// A long, thin burn scar whose southern edge passes within 30 km of the
+	// pad while every vertex is over 300 km away. The edge is a great-circle
+	// arc, which bulges a little north of the 28.8° parallel.
+	scar := NasaEarthEvent{ID: "scar", Geometry: []EventGeometry{
+		{Date: day, Type: GeometryPolygon, Polygon: [][][]float64{{{-84, 28.8}, {-77, 28.8}, {-77, 29.0}, {-84, 29.0}, {-84, 28.8}}}},
+	}}
+	near, ok = scar.Nearest(28.6, -80.6, day, day)
+	assert.True(t, ok)
+	assert.InDelta(t, 27.3, near.DistanceKm, 0.5)
+	assert.InDelta(t, -80.6, near.Closest[0], 0.01)
+	assert.InDelta(t, 28.85, near.Closest[1], 0.01)
+}
@@ -0,0 +1,154 @@
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// UnmarshalJSON accepts the rocket, launchpad, crew and payloads fields
+// either as bare document IDs or as embedded documents returned by the SpaceX
+// populate option. Embedded documents are kept alongside the IDs.
+func (l *Launch) UnmarshalJSON(data []byte) error {
+	type launchAlias Launch
+	aux := struct {
+		*launchAlias
+		Rocket    json.RawMessage   `json:"rocket"`
+		Launchpad json.RawMessage   `json:"launchpad"`
+		Crew      []json.RawMessage `json:"crew"`
+		Payloads  []json.RawMessage `json:"payloads"`
+	}{launchAlias: (*launchAlias)(l)}
+
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	if err := decodeRef(aux.Rocket, &l.RocketId, &l.Rocket, func(r *Rocket) string { return r.ID }); err != nil {
+		return err
+	}
+	if err := decodeRef(aux.Launchpad, &l.LaunchpadId, &l.Launchpad, func(p *Launchpad) string { return p.ID }); err != nil {
+		return err
+	}
+
+	l.Crew = nil
+	l.CrewMembers = nil
+	for _, raw := range aux.Crew {
+		// Newer launches wrap each member as {"crew": ..., "role": ...}.
+		var wrapped struct {
+			Crew json.RawMessage `json:"crew"`
+		}
+		if isObject(raw) && json.Unmarshal(raw, &wrapped) == nil && wrapped.Crew != nil {
+			raw = wrapped.Crew
+		}
+
+		var id string
+		var member *Crew
+		if err := decodeRef(raw, &id, &member, func(c *Crew) string { return c.ID }); err != nil {
+			return err
+		}
+		if id != "" {
+			l.Crew = append(l.Crew, id)
+		}
+		if member != nil {
+			l.CrewMembers = append(l.CrewMembers, *member)
+		}
+	}
+
+	l.Payloads = nil
+	l.PayloadDetails = nil
+	for _, raw := range aux.Payloads {
+		var id string
+		var payload *Payload
+		if err := decodeRef(raw, &id, &payload, func(p *Payload) string { return p.ID }); err != nil {
+			return err
+		}
+		if id != "" {
+			l.Payloads = append(l.Payloads, id)
+		}
+		if payload != nil {
+			l.PayloadDetails = append(l.PayloadDetails, *payload)
+		}
+	}
+	return nil
+}
+
+// UnmarshalJSON accepts the core and landpad fields as IDs or as embedded
+// documents, like Launch.
+func (c *LaunchCore) UnmarshalJSON(data []byte) error {
+	type coreAlias LaunchCore
+	aux := struct {
+		*coreAlias
+		Core    json.RawMessage `json:"core"`
+		Landpad json.RawMessage `json:"landpad"`
+	}{coreAlias: (*coreAlias)(c)}
+
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	if err := decodeRef(aux.Core, &c.CoreID, &c.Core, func(core *Core) string { return core.ID }); err != nil {
+		return err
+	}
+	return decodeRef(aux.Landpad, &c.LandpadID, &c.Landpad, func(p *Landpad) string { return p.ID })
+}
+
+// Launch date precisions reported by the SpaceX API.
+const (
+	PrecisionHalf    = "half"
+	PrecisionQuarter = "quarter"
+	PrecisionYear    = "year"
+	PrecisionMonth   = "month"
+	PrecisionDay     = "day"
+	PrecisionHour    = "hour"
+)
+
+// DisplayDate formats the launch date only as far as it is known, e.g.
+// "2026-11 (month)" for a launch scheduled for some time in November 2026.
+// Dates known to the hour, or without a precision, show the full time.
+func (l Launch) DisplayDate() string {
+	date := l.Date.UTC()
+	var display string
+	switch l.DatePrecision {
+	case PrecisionHalf:
+		display = fmt.Sprintf("%d H%d (half)", date.Year(), (int(date.Month())-1)/6+1)
+	case PrecisionQuarter:
+		display = fmt.Sprintf("%d Q%d (quarter)", date.Year(), (int(date.Month())-1)/3+1)
+	case PrecisionYear:
+		display = fmt.Sprintf("%d (year)", date.Year())
+	case PrecisionMonth:
+		display = date.Format("2006-01") + " (month)"
+	case PrecisionDay:
+		display = date.Format(time.DateOnly) + " (day)"
+	default:
+		display = l.Date.Format("2006-01-02 15:04")
+	}
+	if l.Net {
+		display = "NET " + display
+	}
+	return display
+}
+
+// decodeRef decodes a reference that is either a JSON string ID or an
+// embedded document.
+func decodeRef[T any](raw json.RawMessage, id *string, doc **T, idOf func(*T) string) error {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
+		return nil
+	}
+	if raw[0] == '"' {
+		return json.Unmarshal(raw, id)
+	}
+
+	value := new(T)
+	if err := json.Unmarshal(raw, value); err != nil {
+		return err
+	}
+	*doc = value
+	*id = idOf(value)
+	return nil
+}
+
+func isObject(raw json.RawMessage) bool {
+	raw = bytes.TrimSpace(raw)
+	return len(raw) > 0 && raw[0] == '{'
+}
@@ -0,0 +1,177 @@
+package model
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLaunchUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         string
+		rocketId      string
+		launchpadId   string
+		crew          []string
+		populated     bool
+		crewPopulated []string
+	}{
+		{
+			name:        "references as IDs",
+			input:       `{"name":"Crew-1","rocket":"falcon9","launchpad":"lc39a","crew":["bob","doug"]}`,
+			rocketId:    "falcon9",
+			launchpadId: "lc39a",
+			crew:        []string{"bob", "doug"},
+		},
+		{
+			name:          "populated references",
+			input:         `{"name":"Crew-1","rocket":{"id":"falcon9","name":"Falcon 9","cost_per_launch":50000000},"launchpad":{"id":"lc39a","full_name":"Kennedy Space Center Historic Launch Complex 39A"},"crew":[{"id":"bob","name":"Robert Behnken"}]}`,
+			rocketId:      "falcon9",
+			launchpadId:   "lc39a",
+			crew:          []string{"bob"},
+			populated:     true,
+			crewPopulated: []string{"Robert Behnken"},
+		},
+		{
+			name:          "crew wrapped with roles",
+			input:         `{"name":"Crew-5","rocket":"falcon9","launchpad":"lc39a","crew":[{"crew":"nicole","role":"Commander"},{"crew":{"id":"josh","name":"Josh Cassada"},"role":"Pilot"}]}`,
+			rocketId:      "falcon9",
+			launchpadId:   "lc39a",
+			crew:          []string{"nicole", "josh"},
+			crewPopulated: []string{"Josh Cassada"},
+		},
+		{
+			name:  "missing references",
+			input: `{"name":"Trailblazer","rocket":null}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var launch Launch
+			require.NoError(t, json.Unmarshal([]byte(tt.input), &launch))
+
+			assert.Equal(t, tt.rocketId, launch.RocketId)
+			assert.Equal(t, tt.launchpadId, launch.LaunchpadId)
+			assert.Equal(t, tt.crew, launch.Crew)
+			assert.Equal(t, tt.populated, launch.Rocket != nil)
+			assert.Equal(t, tt.populated, launch.Launchpad != nil)
+			if tt.populated {
+				assert.Equal(t, 50000000, launch.Rocket.CostPerLaunch)
+			}
+
+			var names []string
+			for _, member := range launch.CrewMembers {
+				names = append(names, member.Name)
+			}
+			assert.Equal(t, tt.crewPopulated, names)
+		})
+	}
+}
+
+func TestLaunchUnmarshalJSONFullSchema(t *testing.T) {
+	input := `{
+		"name": "FalconSat",
+		"date_utc": "2006-03-24T22:30:00.000Z",
+		"date_precision": "hour",
+		"net": false,
+		"tbd": false,
+		"window": 0,
+		"static_fire_date_utc": "2006-03-17T00:00:00.000Z",
+		"auto_update": true,
+		"success": false,
+		"failures": [{"time": 33, "altitude": null, "reason": "merlin engine failure"}],
+		"fairings": {"reused": false, "recovery_attempt": false, "recovered": false, "ships": []},
+		"links": {"patch": {"small": "https://images2.imgbox.com/94/f2/NN6Ph45r_o.png"}, "reddit": {"launch": null}, "webcast": "https://www.youtube.com/watch?v=0a_00nJ_Y88", "wikipedia": "https://en.wikipedia.org/wiki/DemoSat"},
+		"payloads": ["5eb0e4b5b6c3bb0006eeb1e1"],
+		"capsules": [],
+		"cores": [{"core": "5e9e289df35918033d3b2623", "flight": 1, "gridfins": false, "legs": false, "reused": false, "landing_attempt": false, "landing_success": null, "landing_type": null, "landpad": null}]
+	}`
+
+	var launch Launch
+	require.NoError(t, json.Unmarshal([]byte(input), &launch))
+
+	assert.Equal(t, PrecisionHour, launch.DatePrecision)
+	require.NotNil(t, launch.Window)
+	assert.Equal(t, 0, *launch.Window)
+	require.NotNil(t, launch.StaticFireDate)
+	assert.Equal(t, "2006-03-17", launch.StaticFireDate.Format("2006-01-02"))
+	assert.True(t, launch.AutoUpdate)
+
+	require.Len(t, launch.Failures, 1)
+	assert.Equal(t, 33, launch.Failures[0].Time)
+	assert.Nil(t, launch.Failures[0].Altitude)
+	assert.Equal(t, "merlin engine failure", launch.Failures[0].Reason)
+
+	require.NotNil(t, launch.Fairings)
+	assert.False(t, *launch.Fairings.RecoveryAttempt)
+	assert.Equal(t, "https://www.youtube.com/watch?v=0a_00nJ_Y88", launch.Links.Webcast)
+	assert.Equal(t, "https://en.wikipedia.org/wiki/DemoSat", launch.Links.Wikipedia)
+	assert.Equal(t, "https://images2.imgbox.com/94/f2/NN6Ph45r_o.png", launch.Links.Patch.Small)
+
+	assert.Equal(t, []string{"5eb0e4b5b6c3bb0006eeb1e1"}, launch.Payloads)
+	assert.Empty(t, launch.Capsules)
+
+	require.Len(t, launch.Cores, 1)
+	core := launch.Cores[0]
+	assert.Equal(t, "5e9e289df35918033d3b2623", core.CoreID)
+	assert.Equal(t, 1, *core.Flight)
+	assert.False(t, *core.LandingAttempt)
+	assert.Nil(t, core.LandingSuccess)
+	assert.Empty(t, core.LandpadID)
+	assert.Nil(t, core.Core)
+}
+
+func TestLaunchUnmarshalJSONPopulatedHardware(t *testing.T) {
+	input := `{
+		"name": "CRS-20",
+		"payloads": [{"id": "crs20", "name": "CRS-20", "type": "Dragon 1.1"}],
+		"cores": [{"core": {"id": "b1059", "serial": "B1059"}, "landing_attempt": true, "landing_success": true, "landing_type": "RTLS", "landpad": {"id": "lz1", "name": "LZ-1"}}]
+	}`
+
+	var launch Launch
+	require.NoError(t, json.Unmarshal([]byte(input), &launch))
+
+	assert.Equal(t, []string{"crs20"}, launch.Payloads)
+	require.Len(t, launch.PayloadDetails, 1)
+	assert.Equal(t, "Dragon 1.1", launch.PayloadDetails[0].Type)
+
+	require.Len(t, launch.Cores, 1)
+	core := launch.Cores[0]
+	assert.Equal(t, "b1059", core.CoreID)
+	require.NotNil(t, core.Core)
+	assert.Equal(t, "B1059", core.Core.Serial)
+	assert.Equal(t, "lz1", core.LandpadID)
+	require.NotNil(t, core.Landpad)
+	assert.Equal(t, "LZ-1", core.Landpad.Name)
+}
+
+func TestLaunchDisplayDate(t *testing.T) {
+	date := time.Date(2026, time.November, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		precision string
+		net       bool
+		expected  string
+	}{
+		{name: "hour", precision: PrecisionHour, expected: "2026-11-01 00:00"},
+		{name: "no precision", expected: "2026-11-01 00:00"},
+		{name: "day", precision: PrecisionDay, expected: "2026-11-01 (day)"},
+		{name: "month", precision: PrecisionMonth, expected: "2026-11 (month)"},
+		{name: "quarter", precision: PrecisionQuarter, expected: "2026 Q4 (quarter)"},
+		{name: "half", precision: PrecisionHalf, expected: "2026 H2 (half)"},
+		{name: "year", precision: PrecisionYear, expected: "2026 (year)"},
+		{name: "no earlier than", precision: PrecisionMonth, net: true, expected: "NET 2026-11 (month)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			launch := Launch{Date: date, DatePrecision: tt.precision, Net: tt.net}
+			assert.Equal(t, tt.expected, launch.DisplayDate())
+		})
+	}
+}
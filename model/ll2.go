@@ -0,0 +1,274 @@
+package model
+
+import (
+	"bytes"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Launch Library 2 launch status IDs.
+const (
+	LL2StatusGo             = 1
+	LL2StatusTBD            = 2
+	LL2StatusSuccess        = 3
+	LL2StatusFailure        = 4
+	LL2StatusHold           = 5
+	LL2StatusInFlight       = 6
+	LL2StatusPartialFailure = 7
+	LL2StatusTBC            = 8
+)
+
+// LL2Page is one page of a Launch Library 2 list endpoint.
+type LL2Page[T any] struct {
+	Count    int     `json:"count"`
+	Next     *string `json:"next"`
+	Previous *string `json:"previous"`
+	Results  []T     `json:"results"`
+}
+
+// LL2Number is a number that Launch Library 2 sends either as a JSON number
+// or as a string, such as pad coordinates and launch costs. Null and empty
+// strings decode as 0.
+type LL2Number float64
+
+func (n *LL2Number) UnmarshalJSON(data []byte) error {
+	data = bytes.Trim(data, `"`)
+	if len(data) == 0 || string(data) == "null" {
+		*n = 0
+		return nil
+	}
+	value, err := strconv.ParseFloat(string(data), 64)
+	if err != nil {
+		return err
+	}
+	*n = LL2Number(value)
+	return nil
+}
+
+// LL2Launch is a Launch Library 2 launch in the default (normal) mode.
+type LL2Launch struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Status struct {
+		ID     int    `json:"id"`
+		Name   string `json:"name"`
+		Abbrev string `json:"abbrev"`
+	} `json:"status"`
+	Net          time.Time `json:"net"`
+	NetPrecision *struct {
+		Name   string `json:"name"`
+		Abbrev string `json:"abbrev"`
+	} `json:"net_precision"`
+	WindowStart           *time.Time `json:"window_start"`
+	WindowEnd             *time.Time `json:"window_end"`
+	FailReason            string     `json:"failreason"`
+	LaunchServiceProvider LL2Agency  `json:"launch_service_provider"`
+	Rocket                struct {
+		ID            int               `json:"id"`
+		Configuration LL2LauncherConfig `json:"configuration"`
+	} `json:"rocket"`
+	Mission *struct {
+		Name        string `json:"name"`
+		Description string `json:"description"`
+		Type        string `json:"type"`
+		Orbit       *struct {
+			Name   string `json:"name"`
+			Abbrev string `json:"abbrev"`
+		} `json:"orbit"`
+	} `json:"mission"`
+	Pad     LL2Pad `json:"pad"`
+	VidURLs []struct {
+		URL string `json:"url"`
+	} `json:"vidURLs"`
+	WebcastLive bool `json:"webcast_live"`
+}
+
+// LL2Pad is a Launch Library 2 launch pad.
+type LL2Pad struct {
+	ID        int       `json:"id"`
+	Name      string    `json:"name"`
+	Latitude  LL2Number `json:"latitude"`
+	Longitude LL2Number `json:"longitude"`
+	Location  struct {
+		Name        string `json:"name"`
+		CountryCode string `json:"country_code"`
+	} `json:"location"`
+	Description      string `json:"description"`
+	WikiURL          string `json:"wiki_url"`
+	TotalLaunchCount int    `json:"total_launch_count"`
+}
+
+// LL2LauncherConfig is a Launch Library 2 launcher configuration, which is
+// what the CLI calls a rocket.
+type LL2LauncherConfig struct {
+	ID           int        `json:"id"`
+	Name         string     `json:"name"`
+	FullName     string     `json:"full_name"`
+	Family       string     `json:"family"`
+	Variant      string     `json:"variant"`
+	Description  string     `json:"description"`
+	Active       bool       `json:"active"`
+	Manufacturer *LL2Agency `json:"manufacturer"`
+	// Length and Diameter are in meters, LaunchMass in tonnes.
+	Length             *float64  `json:"length"`
+	Diameter           *float64  `json:"diameter"`
+	LaunchMass         *float64  `json:"launch_mass"`
+	LaunchCost         LL2Number `json:"launch_cost"`
+	MaidenFlight       string    `json:"maiden_flight"`
+	TotalLaunchCount   int       `json:"total_launch_count"`
+	SuccessfulLaunches int       `json:"successful_launches"`
+}
+
+// LL2Agency is a Launch Library 2 agency.
+type LL2Agency struct {
+	ID            int    `json:"id"`
+	Name          string `json:"name"`
+	Abbrev        string `json:"abbrev"`
+	Type          string `json:"type"`
+	CountryCode   string `json:"country_code"`
+	Description   string `json:"description"`
+	Administrator string `json:"administrator"`
+	FoundingYear  string `json:"founding_year"`
+	Launchers     string `json:"launchers"`
+	Spacecraft    string `json:"spacecraft"`
+}
+
+// Launch maps the launch onto the shared model. Its rocket and launchpad are
+// embedded as if the launch had been populated, and its ID doubles as the
+// launch library ID so that the same launch from SpaceX can be recognised.
+func (l LL2Launch) Launch() Launch {
+	launch := Launch{
+		ID:              l.ID,
+		Name:            l.Name,
+		Date:            l.Net,
+		DatePrecision:   l.datePrecision(),
+		TBD:             l.Status.ID == LL2StatusTBD,
+		RocketId:        strconv.Itoa(l.Rocket.Configuration.ID),
+		LaunchpadId:     strconv.Itoa(l.Pad.ID),
+		LaunchLibraryID: &l.ID,
+		Agency:          l.LaunchServiceProvider.Name,
+	}
+	// Names read "Falcon 9 Block 5 | Starlink Group 6-1"; the rocket is
+	// shown separately.
+	if _, mission, ok := strings.Cut(l.Name, " | "); ok {
+		launch.Name = mission
+	}
+
+	switch l.Status.ID {
+	case LL2StatusSuccess:
+		success := true
+		launch.Success = &success
+	case LL2StatusFailure, LL2StatusPartialFailure:
+		success := false
+		launch.Success = &success
+	case LL2StatusGo, LL2StatusTBD, LL2StatusHold, LL2StatusTBC:
+		launch.Upcoming = true
+	}
+
+	if l.WindowStart != nil && l.WindowEnd != nil {
+		window := int(l.WindowEnd.Sub(*l.WindowStart).Seconds())
+		launch.Window = &window
+	}
+	if l.FailReason != "" {
+		launch.Failures = []LaunchFailure{{Reason: l.FailReason}}
+	}
+	if l.Mission != nil {
+		launch.Details = l.Mission.Description
+	}
+	if len(l.VidURLs) > 0 {
+		launch.Links.Webcast = l.VidURLs[0].URL
+	}
+
+	rocket := l.Rocket.Configuration.Rocket()
+	launchpad := l.Pad.Launchpad()
+	launch.Rocket = &rocket
+	launch.Launchpad = &launchpad
+	return launch
+}
+
+// datePrecision maps the LL2 net precision onto the SpaceX date precisions.
+func (l LL2Launch) datePrecision() string {
+	if l.NetPrecision == nil {
+		return ""
+	}
+	switch abbrev := strings.ToUpper(l.NetPrecision.Abbrev); {
+	case abbrev == "SEC", abbrev == "MIN", abbrev == "HR":
+		return PrecisionHour
+	case abbrev == "DAY":
+		return PrecisionDay
+	case abbrev == "MON":
+		return PrecisionMonth
+	case strings.HasPrefix(abbrev, "Q"):
+		return PrecisionQuarter
+	case abbrev == "H1", abbrev == "H2":
+		return PrecisionHalf
+	case strings.HasPrefix(abbrev, "Y"):
+		return PrecisionYear
+	default:
+		return ""
+	}
+}
+
+func (p LL2Pad) Launchpad() Launchpad {
+	return Launchpad{
+		ID:        strconv.Itoa(p.ID),
+		Name:      p.Name,
+		Locality:  p.Location.Name,
+		Latitude:  float64(p.Latitude),
+		Longitude: float64(p.Longitude),
+		Details:   p.Description,
+	}
+}
+
+func (c LL2LauncherConfig) Rocket() Rocket {
+	rocket := Rocket{
+		ID:            strconv.Itoa(c.ID),
+		Name:          c.FullName,
+		CostPerLaunch: int(c.LaunchCost),
+		FirstFlight:   c.MaidenFlight,
+		Description:   c.Description,
+	}
+	if rocket.Name == "" {
+		rocket.Name = c.Name
+	}
+	if c.TotalLaunchCount > 0 {
+		rocket.SuccessRate = int(math.Round(100 * float64(c.SuccessfulLaunches) / float64(c.TotalLaunchCount)))
+	}
+	if c.Manufacturer != nil {
+		rocket.Company = c.Manufacturer.Name
+		rocket.Country = c.Manufacturer.CountryCode
+	}
+	if c.Length != nil {
+		rocket.Height = Length{Meters: float32(*c.Length), Feet: float32(*c.Length * feetPerMeter)}
+	}
+	if c.Diameter != nil {
+		rocket.Diameter = Length{Meters: float32(*c.Diameter), Feet: float32(*c.Diameter * feetPerMeter)}
+	}
+	if c.LaunchMass != nil {
+		kg := *c.LaunchMass * 1000
+		rocket.Mass = Mass{Kg: float32(kg), Lb: float32(kg * poundsPerKg)}
+	}
+	return rocket
+}
+
+func (a LL2Agency) Agency() Agency {
+	return Agency{
+		ID:            strconv.Itoa(a.ID),
+		Name:          a.Name,
+		Abbrev:        a.Abbrev,
+		Type:          a.Type,
+		CountryCode:   a.CountryCode,
+		Description:   a.Description,
+		Administrator: a.Administrator,
+		FoundingYear:  a.FoundingYear,
+		Launchers:     a.Launchers,
+		Spacecraft:    a.Spacecraft,
+	}
+}
+
+const (
+	feetPerMeter = 3.28084
+	poundsPerKg  = 2.20462
+)
@@ -0,0 +1,121 @@
+package model
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLL2LaunchToLaunch(t *testing.T) {
+	input := `{
+		"id": "e3df2ecd-c239-472f-95e4-2b89b4f75800",
+		"name": "Electron | It's Business Time",
+		"status": {"id": 4, "name": "Launch Failure", "abbrev": "Failure"},
+		"net": "2020-07-04T21:19:36Z",
+		"net_precision": {"id": 1, "name": "Minute", "abbrev": "MIN"},
+		"window_start": "2020-07-04T21:19:00Z",
+		"window_end": "2020-07-04T23:19:00Z",
+		"failreason": "Second stage motor failure",
+		"launch_service_provider": {"id": 147, "name": "Rocket Lab", "type": "Commercial"},
+		"rocket": {"id": 2929, "configuration": {"id": 26, "name": "Electron", "full_name": "Electron", "launch_cost": "7500000", "total_launch_count": 50, "successful_launches": 45, "manufacturer": {"id": 147, "name": "Rocket Lab", "country_code": "NZL"}, "length": 18.0}},
+		"mission": {"name": "Pics Or It Didn't Happen", "description": "Rideshare mission to Sun-synchronous orbit.", "orbit": {"name": "Sun-Synchronous Orbit", "abbrev": "SSO"}},
+		"pad": {"id": 166, "name": "Rocket Lab Launch Complex 1A", "latitude": "-39.26085", "longitude": "177.864829", "location": {"name": "Onenui Station, Mahia Peninsula, New Zealand", "country_code": "NZL"}}
+	}`
+
+	var raw LL2Launch
+	require.NoError(t, json.Unmarshal([]byte(input), &raw))
+	launch := raw.Launch()
+
+	assert.Equal(t, "e3df2ecd-c239-472f-95e4-2b89b4f75800", launch.ID)
+	assert.Equal(t, "It's Business Time", launch.Name)
+	assert.Equal(t, time.Date(2020, 7, 4, 21, 19, 36, 0, time.UTC), launch.Date)
+	assert.Equal(t, PrecisionHour, launch.DatePrecision)
+	require.NotNil(t, launch.Success)
+	assert.False(t, *launch.Success)
+	assert.False(t, launch.Upcoming)
+	assert.Equal(t, []LaunchFailure{{Reason: "Second stage motor failure"}}, launch.Failures)
+	assert.Equal(t, 7200, *launch.Window)
+	assert.Equal(t, "Rocket Lab", launch.Agency)
+	assert.Equal(t, "Rideshare mission to Sun-synchronous orbit.", launch.Details)
+	assert.Equal(t, launch.ID, *launch.LaunchLibraryID)
+
+	assert.Equal(t, "26", launch.RocketId)
+	require.NotNil(t, launch.Rocket)
+	assert.Equal(t, 7500000, launch.Rocket.CostPerLaunch)
+	assert.Equal(t, 90, launch.Rocket.SuccessRate)
+	assert.Equal(t, "NZL", launch.Rocket.Country)
+	assert.InDelta(t, 59.06, launch.Rocket.Height.Feet, 0.01)
+
+	assert.Equal(t, "166", launch.LaunchpadId)
+	require.NotNil(t, launch.Launchpad)
+	assert.Equal(t, -39.26085, launch.Launchpad.Latitude)
+	assert.Equal(t, 177.864829, launch.Launchpad.Longitude)
+	assert.Equal(t, "Onenui Station, Mahia Peninsula, New Zealand", launch.Launchpad.Locality)
+}
+
+func TestLL2LaunchStatusAndPrecision(t *testing.T) {
+	succeeded, failed := true, false
+	tests := []struct {
+		name      string
+		status    int
+		precision string
+		success   *bool
+		upcoming  bool
+		expected  string
+	}{
+		{name: "to be determined", status: LL2StatusTBD, precision: "MON", upcoming: true, expected: PrecisionMonth},
+		{name: "go for launch", status: LL2StatusGo, precision: "DAY", upcoming: true, expected: PrecisionDay},
+		{name: "quarter", status: LL2StatusTBC, precision: "Q4", upcoming: true, expected: PrecisionQuarter},
+		{name: "half", status: LL2StatusTBD, precision: "H2", upcoming: true, expected: PrecisionHalf},
+		{name: "year", status: LL2StatusTBD, precision: "Y", upcoming: true, expected: PrecisionYear},
+		{name: "success", status: LL2StatusSuccess, precision: "SEC", success: &succeeded, expected: PrecisionHour},
+		{name: "partial failure", status: LL2StatusPartialFailure, success: &failed},
+		{name: "in flight", status: LL2StatusInFlight},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var raw LL2Launch
+			raw.Status.ID = tt.status
+			if tt.precision != "" {
+				raw.NetPrecision = &struct {
+					Name   string `json:"name"`
+					Abbrev string `json:"abbrev"`
+				}{Abbrev: tt.precision}
+			}
+
+			launch := raw.Launch()
+			assert.Equal(t, tt.success, launch.Success)
+			assert.Equal(t, tt.upcoming, launch.Upcoming)
+			assert.Equal(t, tt.status == LL2StatusTBD, launch.TBD)
+			assert.Equal(t, tt.expected, launch.DatePrecision)
+		})
+	}
+}
+
+func TestLL2Number(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected LL2Number
+	}{
+		{input: `28.56194122`, expected: 28.56194122},
+		{input: `"28.56194122"`, expected: 28.56194122},
+		{input: `"62000000"`, expected: 62000000},
+		{input: `null`, expected: 0},
+		{input: `""`, expected: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			var n LL2Number
+			require.NoError(t, json.Unmarshal([]byte(tt.input), &n))
+			assert.Equal(t, tt.expected, n)
+		})
+	}
+
+	var n LL2Number
+	assert.Error(t, json.Unmarshal([]byte(`"north"`), &n))
+}
@@ -7,11 +7,113 @@ type Launch struct {
 	FlightNumber int       `json:"flight_number"`
 	Name         string    `json:"name"`
 	Date         time.Time `json:"date_utc"`
-	Success      *bool     `json:"success"`
-	Crew         []string  `json:"crew"`
-	RocketId     string    `json:"rocket"`
-	Details      string    `json:"details"`
-	LaunchpadId  string    `json:"launchpad"`
+	DateLocal    string    `json:"date_local"`
+	// DatePrecision is how much of Date is known: half, quarter, year,
+	// month, day or hour.
+	DatePrecision string `json:"date_precision"`
+	// Net marks Date as "no earlier than"; TBD marks it as unconfirmed.
+	Net bool `json:"net"`
+	TBD bool `json:"tbd"`
+	// Window is the length of the launch window in seconds, 0 for an
+	// instantaneous window and nil when unknown.
+	Window         *int       `json:"window"`
+	StaticFireDate *time.Time `json:"static_fire_date_utc"`
+	AutoUpdate     bool       `json:"auto_update"`
+	Success        *bool      `json:"success"`
+	Upcoming       bool       `json:"upcoming"`
+	Crew           []string   `json:"crew"`
+	RocketId       string     `json:"rocket"`
+	Details        string     `json:"details"`
+	LaunchpadId    string     `json:"launchpad"`
+
+	Cores    []LaunchCore    `json:"cores"`
+	Payloads []string        `json:"payloads"`
+	Capsules []string        `json:"capsules"`
+	Ships    []string        `json:"ships"`
+	Fairings *Fairings       `json:"fairings"`
+	Failures []LaunchFailure `json:"failures"`
+	Links    LaunchLinks     `json:"links"`
+
+	// LaunchLibraryID is the Launch Library 2 ID of the same launch.
+	LaunchLibraryID *string `json:"launch_library_id"`
+	// Provider names the source the launch came from, e.g. "spacex" or
+	// "ll2"; Agency is the launch service provider flying it.
+	Provider string `json:"provider,omitempty"`
+	Agency   string `json:"agency,omitempty"`
+
+	// Documents embedded by the SpaceX populate option; nil otherwise.
+	Rocket         *Rocket    `json:"-"`
+	Launchpad      *Launchpad `json:"-"`
+	CrewMembers    []Crew     `json:"-"`
+	PayloadDetails []Payload  `json:"-"`
+}
+
+// LaunchCore is one first stage flown on a launch and its landing.
+type LaunchCore struct {
+	CoreID         string `json:"core"`
+	Flight         *int   `json:"flight"`
+	Gridfins       *bool  `json:"gridfins"`
+	Legs           *bool  `json:"legs"`
+	Reused         *bool  `json:"reused"`
+	LandingAttempt *bool  `json:"landing_attempt"`
+	LandingSuccess *bool  `json:"landing_success"`
+	// LandingType is ASDS, RTLS or Ocean.
+	LandingType string `json:"landing_type"`
+	LandpadID   string `json:"landpad"`
+
+	// Documents embedded by the SpaceX populate option; nil otherwise.
+	Core    *Core    `json:"-"`
+	Landpad *Landpad `json:"-"`
+}
+
+type Fairings struct {
+	Reused          *bool    `json:"reused"`
+	RecoveryAttempt *bool    `json:"recovery_attempt"`
+	Recovered       *bool    `json:"recovered"`
+	Ships           []string `json:"ships"`
+}
+
+type LaunchFailure struct {
+	// Time is seconds after liftoff.
+	Time     int    `json:"time"`
+	Altitude *int   `json:"altitude"`
+	Reason   string `json:"reason"`
+}
+
+type LaunchLinks struct {
+	Patch struct {
+		Small string `json:"small"`
+		Large string `json:"large"`
+	} `json:"patch"`
+	Reddit struct {
+		Campaign string `json:"campaign"`
+		Launch   string `json:"launch"`
+		Media    string `json:"media"`
+		Recovery string `json:"recovery"`
+	} `json:"reddit"`
+	Flickr struct {
+		Small    []string `json:"small"`
+		Original []string `json:"original"`
+	} `json:"flickr"`
+	Presskit  string `json:"presskit"`
+	Webcast   string `json:"webcast"`
+	YoutubeID string `json:"youtube_id"`
+	Article   string `json:"article"`
+	Wikipedia string `json:"wikipedia"`
+}
+
+// Agency is a space agency or launch service provider.
+type Agency struct {
+	ID            string `json:"id"`
+	Name          string `json:"name"`
+	Abbrev        string `json:"abbrev"`
+	Type          string `json:"type"`
+	CountryCode   string `json:"country_code"`
+	Description   string `json:"description"`
+	Administrator string `json:"administrator"`
+	FoundingYear  string `json:"founding_year"`
+	Launchers     string `json:"launchers"`
+	Spacecraft    string `json:"spacecraft"`
 }
 
 type Launchpad struct {
@@ -57,34 +159,7 @@ type Length struct {
 }
 
 // NASA API Models
-type NasaEarthEvent struct {
-	ID          string `json:"id"`
-	Title       string `json:"title"`
-	Description string `json:"description"`
-	Categories  []struct {
-		ID    string `json:"id"`
-		Title string `json:"title"`
-	} `json:"categories"`
-	Geometry []struct {
-		Type        string    `json:"type"`
-		Coordinates []float64 `json:"coordinates"`
-		Date        string    `json:"date"`
-	} `json:"geometry"`
-}
-
-type NasaEarth struct {
-	Events []NasaEarthEvent `json:"events"`
-}
 type NasaAsteroid struct {
 	ElementCount     int                             `json:"element_count"`
 	NearEarthObjects map[string][]NasaAsteroidObject `json:"near_earth_objects"`
 }
-
-type NasaAsteroidObject struct {
-	Hazardous bool `json:"is_potentially_hazardous_asteroid"`
-	Diameter  struct {
-		Meters struct {
-			Estimated float64 `json:"estimated_diameter_max"`
-		} `json:"meters"`
-	} `json:"estimated_diameter"`
-}
@@ -0,0 +1,110 @@
+package model
+
+import (
+	"slices"
+	"time"
+)
+
+// POWER daily parameters requested for a launchpad.
+const (
+	PowerTemperature    = "T2M"
+	PowerMaxTemperature = "T2M_MAX"
+	PowerMinTemperature = "T2M_MIN"
+	PowerWindSpeed      = "WS10M"
+	PowerMaxWindSpeed   = "WS10M_MAX"
+	PowerPrecipitation  = "PRECTOTCORR"
+	PowerCloudCover     = "CLOUD_AMT"
+)
+
+// PowerParameters lists every parameter decoded into DailyWeather.
+var PowerParameters = []string{
+	PowerTemperature, PowerMaxTemperature, PowerMinTemperature,
+	PowerWindSpeed, PowerMaxWindSpeed, PowerPrecipitation, PowerCloudCover,
+}
+
+const powerDateLayout = "20060102"
+
+// PowerFirstDay is the first day of POWER's daily meteorology; earlier days
+// are rejected.
+var PowerFirstDay = time.Date(1981, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// NasaPower is a NASA POWER daily point response. Parameter values are
+// keyed by parameter name and then by YYYYMMDD date.
+type NasaPower struct {
+	Geometry struct {
+		// Coordinates are longitude, latitude and elevation in meters.
+		Coordinates []float64 `json:"coordinates"`
+	} `json:"geometry"`
+	Properties struct {
+		Parameter map[string]map[string]float64 `json:"parameter"`
+	} `json:"properties"`
+	Header struct {
+		Title string `json:"title"`
+		// FillValue marks days without data, e.g. days after the
+		// latest processed date.
+		FillValue *float64 `json:"fill_value"`
+	} `json:"header"`
+	Messages []string `json:"messages"`
+}
+
+// DailyWeather is the weather at a point on one day. Values are nil where
+// POWER has no data yet.
+type DailyWeather struct {
+	Date time.Time
+	// Temperatures at 2 m in °C.
+	Temperature    *float64
+	MaxTemperature *float64
+	MinTemperature *float64
+	// Wind speeds at 10 m in m/s.
+	WindSpeed    *float64
+	MaxWindSpeed *float64
+	// Precipitation in mm/day.
+	Precipitation *float64
+	// CloudCover is the cloud amount in percent.
+	CloudCover *float64
+}
+
+// Available reports whether any value is known for the day.
+func (w DailyWeather) Available() bool {
+	return w.Temperature != nil || w.WindSpeed != nil || w.Precipitation != nil || w.CloudCover != nil
+}
+
+// Days returns the weather for each day in the response, in date order.
+func (p NasaPower) Days() []DailyWeather {
+	dates := []string{}
+	for _, values := range p.Properties.Parameter {
+		for date := range values {
+			if !slices.Contains(dates, date) {
+				dates = append(dates, date)
+			}
+		}
+	}
+	slices.Sort(dates)
+
+	days := []DailyWeather{}
+	for _, date := range dates {
+		day, err := time.Parse(powerDateLayout, date)
+		if err != nil {
+			continue
+		}
+		days = append(days, DailyWeather{
+			Date:           day,
+			Temperature:    p.value(PowerTemperature, date),
+			MaxTemperature: p.value(PowerMaxTemperature, date),
+			MinTemperature: p.value(PowerMinTemperature, date),
+			WindSpeed:      p.value(PowerWindSpeed, date),
+			MaxWindSpeed:   p.value(PowerMaxWindSpeed, date),
+			Precipitation:  p.value(PowerPrecipitation, date),
+			CloudCover:     p.value(PowerCloudCover, date),
+		})
+	}
+	return days
+}
+
+func (p NasaPower) value(parameter, date string) *float64 {
+	value, ok := p.Properties.Parameter[parameter][date]
+	if !ok || (p.Header.FillValue != nil && value == *p.Header.FillValue) {
+		return nil
+	}
+	return &value
+}
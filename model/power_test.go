@@ -0,0 +1,47 @@
+package model
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNasaPowerDays(t *testing.T) {
+	var power NasaPower
+	require.NoError(t, json.Unmarshal([]byte(`{
+	  "type": "Feature",
+	  "geometry": {"type": "Point", "coordinates": [-80.6, 28.6, 4.12]},
+	  "properties": {
+	    "parameter": {
+	      "T2M": {"20240925": 26.4, "20240926": 27.1},
+	      "T2M_MAX": {"20240925": 30.2, "20240926": 31.4},
+	      "T2M_MIN": {"20240925": 23.0, "20240926": 22.0},
+	      "WS10M": {"20240925": 3.9, "20240926": 6.5},
+	      "PRECTOTCORR": {"20240925": 0.0, "20240926": 12.3},
+	      "CLOUD_AMT": {"20240925": 41.0, "20240926": -999.0}
+	    }
+	  },
+	  "header": {"title": "NASA/POWER Source Native Resolution Daily Data", "fill_value": -999.0},
+	  "messages": []
+	}`), &power))
+
+	days := power.Days()
+	require.Len(t, days, 2)
+
+	first := days[0]
+	assert.Equal(t, time.Date(2024, 9, 25, 0, 0, 0, 0, time.UTC), first.Date)
+	assert.Equal(t, 26.4, *first.Temperature)
+	assert.Equal(t, 0.0, *first.Precipitation, "zero precipitation is a value, not missing data")
+	assert.Equal(t, 41.0, *first.CloudCover)
+	assert.Nil(t, first.MaxWindSpeed)
+
+	second := days[1]
+	assert.Equal(t, 12.3, *second.Precipitation)
+	assert.Nil(t, second.CloudCover, "fill values are missing data")
+	assert.True(t, second.Available())
+
+	assert.False(t, DailyWeather{}.Available())
+}